@@ -2,6 +2,7 @@ package app
 
 import (
 	"encoding/json"
+	"github.com/rune/baseapp/x/auction"
 	"github.com/rune/baseapp/x/nameservice"
 	"io"
 	"os"
@@ -20,16 +21,29 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/auth/vesting"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/crisis"
 	distr "github.com/cosmos/cosmos-sdk/x/distribution"
 	"github.com/cosmos/cosmos-sdk/x/genutil"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/params"
+	paramsclient "github.com/cosmos/cosmos-sdk/x/params/client"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/staking"
 	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+	upgradeclient "github.com/cosmos/cosmos-sdk/x/upgrade/client"
 )
 
 const appName = "app"
 
+// upgradeNameserviceV2 is the upgrade plan moving the nameservice and auction state to
+// nameservice consensus version 2
+const upgradeNameserviceV2 = "nameservice-v2"
+
+// upgradeNameserviceV3 is the upgrade plan adding the record gas and rent params
+const upgradeNameserviceV3 = "nameservice-v3"
+
 var (
 	// TODO: rename your cli
 
@@ -53,8 +67,12 @@ var (
 		params.AppModuleBasic{},
 		slashing.AppModuleBasic{},
 		supply.AppModuleBasic{},
+		crisis.AppModuleBasic{},
+		gov.NewAppModuleBasic(paramsclient.ProposalHandler, upgradeclient.ProposalHandler),
+		upgrade.AppModuleBasic{},
 		// TODO: Add your module(s) AppModuleBasic
 		nameservice.AppModule{},
+		auction.AppModuleBasic{},
 	)
 
 	// module account permissions
@@ -63,6 +81,8 @@ var (
 		distr.ModuleName:          nil,
 		staking.BondedPoolName:    {supply.Burner, supply.Staking},
 		staking.NotBondedPoolName: {supply.Burner, supply.Staking},
+		gov.ModuleName:            {supply.Burner},
+		auction.ModuleName:        nil,
 	}
 )
 
@@ -86,6 +106,10 @@ type NewApp struct {
 
 	invCheckPeriod uint
 
+	// settleAuctionsOnExport makes a zero-height export settle the open auctions instead of
+	// carrying them over
+	settleAuctionsOnExport bool
+
 	// keys to access the substores
 	keys  map[string]*sdk.KVStoreKey
 	tKeys map[string]*sdk.TransientStoreKey
@@ -101,7 +125,11 @@ type NewApp struct {
 	distrKeeper    distr.Keeper
 	supplyKeeper   supply.Keeper
 	paramsKeeper   params.Keeper
+	crisisKeeper   crisis.Keeper
+	govKeeper      gov.Keeper
+	upgradeKeeper  upgrade.Keeper
 	nsKeeper       nameservice.Keeper
+	auctionKeeper  auction.Keeper
 	// TODO: Add your module(s)
 
 	// Module Manager
@@ -117,7 +145,7 @@ var _ simapp.App = (*NewApp)(nil)
 // NewbaseappApp is a constructor function for baseappApp
 func NewInitApp(
 	logger log.Logger, db dbm.DB, traceStore io.Writer, loadLatest bool,
-	invCheckPeriod uint, baseAppOptions ...func(*bam.BaseApp),
+	skipUpgradeHeights map[int64]bool, invCheckPeriod uint, baseAppOptions ...func(*bam.BaseApp),
 ) *NewApp {
 	// First define the top level codec that will be shared by the different modules
 	cdc := MakeCodec()
@@ -129,8 +157,8 @@ func NewInitApp(
 
 	// TODO: Add the keys that module requires
 	keys := sdk.NewKVStoreKeys(bam.MainStoreKey, auth.StoreKey, staking.StoreKey,
-		supply.StoreKey, distr.StoreKey, slashing.StoreKey, params.StoreKey, nameservice.StoreKey,
-		nameservice.AuctionKey)
+		supply.StoreKey, distr.StoreKey, slashing.StoreKey, params.StoreKey, gov.StoreKey,
+		upgrade.StoreKey, nameservice.StoreKey, auction.StoreKey)
 
 	tKeys := sdk.NewTransientStoreKeys(staking.TStoreKey, params.TStoreKey)
 
@@ -152,6 +180,10 @@ func NewInitApp(
 	app.subspaces[staking.ModuleName] = app.paramsKeeper.Subspace(staking.DefaultParamspace)
 	app.subspaces[distr.ModuleName] = app.paramsKeeper.Subspace(distr.DefaultParamspace)
 	app.subspaces[slashing.ModuleName] = app.paramsKeeper.Subspace(slashing.DefaultParamspace)
+	app.subspaces[crisis.ModuleName] = app.paramsKeeper.Subspace(crisis.DefaultParamspace)
+	app.subspaces[gov.ModuleName] = app.paramsKeeper.Subspace(gov.DefaultParamspace).WithKeyTable(gov.ParamKeyTable())
+	app.subspaces[nameservice.ModuleName] = app.paramsKeeper.Subspace(nameservice.DefaultParamspace)
+	app.subspaces[auction.ModuleName] = app.paramsKeeper.Subspace(auction.DefaultParamspace)
 
 	// The AccountKeeper handles address -> account lookups
 	app.accountKeeper = auth.NewAccountKeeper(
@@ -202,6 +234,29 @@ func NewInitApp(
 		app.subspaces[slashing.ModuleName],
 	)
 
+	app.crisisKeeper = crisis.NewKeeper(
+		app.subspaces[crisis.ModuleName],
+		invCheckPeriod,
+		app.supplyKeeper,
+		auth.FeeCollectorName,
+	)
+
+	app.upgradeKeeper = upgrade.NewKeeper(skipUpgradeHeights, keys[upgrade.StoreKey], app.cdc)
+
+	// register the proposal types
+	govRouter := gov.NewRouter()
+	govRouter.AddRoute(gov.RouterKey, gov.ProposalHandler).
+		AddRoute(params.RouterKey, params.NewParamChangeProposalHandler(app.paramsKeeper)).
+		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.upgradeKeeper))
+	app.govKeeper = gov.NewKeeper(
+		app.cdc,
+		keys[gov.StoreKey],
+		app.subspaces[gov.ModuleName],
+		app.supplyKeeper,
+		&stakingKeeper,
+		govRouter,
+	)
+
 	// register the staking hooks
 	// NOTE: stakingKeeper above is passed by reference, so that it will contain these hooks
 	app.stakingKeeper = *stakingKeeper.SetHooks(
@@ -211,12 +266,63 @@ func NewInitApp(
 	)
 
 	// TODO: Add your module(s) keepers
-	app.nsKeeper = nameservice.NewKeeper(
+	nsKeeper := nameservice.NewKeeper(
 		app.bankKeeper,
+		app.supplyKeeper,
 		keys[nameservice.StoreKey],
 		app.cdc,
+		auth.FeeCollectorName,
+		app.subspaces[nameservice.ModuleName],
+	)
+
+	// register the nameservice hooks
+	// NOTE: the hooks have to be set before the keeper is copied into the assets and modules below
+	app.nsKeeper = *nsKeeper.SetHooks(
+		nameservice.NewMultiNameserviceHooks(),
+	)
+
+	// register the assets auctions can sell
+	auctionRouter := auction.NewAssetRouter()
+	auctionRouter.
+		AddRoute(nameservice.AssetRoute, nameservice.NewNameAsset(app.nsKeeper)).
+		AddRoute(auction.CoinsAssetRoute, auction.NewCoinsAsset(app.supplyKeeper))
+
+	app.auctionKeeper = auction.NewKeeper(
+		app.bankKeeper,
+		app.supplyKeeper,
+		keys[auction.StoreKey],
+		app.cdc,
+		auth.FeeCollectorName,
+		auctionRouter,
+		app.subspaces[auction.ModuleName],
 	)
 
+	// the upgrade handlers bring the stored state up to the consensus version of this binary
+	nsMigrations := nameservice.NewMigrations(app.nsKeeper).
+		Register(1, func(ctx sdk.Context) error {
+			// auction records shared the nameservice store, they have to move out first
+			app.auctionKeeper.MigrateStore(ctx, keys[nameservice.StoreKey])
+			app.nsKeeper.MigrateStore(ctx)
+			// the knobs were hard-coded before, their values become the stored params
+			app.nsKeeper.SetParams(ctx, nameservice.DefaultParams())
+			app.auctionKeeper.SetParams(ctx, auction.DefaultParams())
+			return app.auctionKeeper.MigrateAuctionEncoding(ctx, nameservice.AssetRoute)
+		}).
+		Register(2, func(ctx sdk.Context) error {
+			app.nsKeeper.MigrateParams(ctx)
+			app.auctionKeeper.MigrateParams(ctx)
+			app.nsKeeper.MigrateRent(ctx)
+			return nil
+		})
+	// either plan brings the store up to the latest version
+	migrateNameservice := func(ctx sdk.Context, _ upgrade.Plan) {
+		if err := nsMigrations.Migrate(ctx); err != nil {
+			panic(err)
+		}
+	}
+	app.upgradeKeeper.SetUpgradeHandler(upgradeNameserviceV2, migrateNameservice)
+	app.upgradeKeeper.SetUpgradeHandler(upgradeNameserviceV3, migrateNameservice)
+
 	// NOTE: Any module instantiated in the module manager that is later modified
 	// must be passed by reference here.
 	app.mm = module.NewManager(
@@ -224,10 +330,14 @@ func NewInitApp(
 		auth.NewAppModule(app.accountKeeper),
 		bank.NewAppModule(app.bankKeeper, app.accountKeeper),
 		supply.NewAppModule(app.supplyKeeper, app.accountKeeper),
+		crisis.NewAppModule(&app.crisisKeeper),
 		distr.NewAppModule(app.distrKeeper, app.accountKeeper, app.supplyKeeper, app.stakingKeeper),
 		slashing.NewAppModule(app.slashingKeeper, app.accountKeeper, app.stakingKeeper),
+		gov.NewAppModule(app.govKeeper, app.accountKeeper, app.supplyKeeper),
+		upgrade.NewAppModule(app.upgradeKeeper),
 		// TODO: Add your module(s)
-		nameservice.NewAppModule(app.nsKeeper, app.bankKeeper),
+		nameservice.NewAppModule(app.nsKeeper, app.bankKeeper, app.accountKeeper, app.auctionKeeper),
+		auction.NewAppModule(app.auctionKeeper),
 		staking.NewAppModule(app.stakingKeeper, app.accountKeeper, app.supplyKeeper),
 		slashing.NewAppModule(app.slashingKeeper, app.accountKeeper, app.stakingKeeper),
 	)
@@ -235,27 +345,54 @@ func NewInitApp(
 	// there is nothing left over in the validator fee pool, so as to keep the
 	// CanWithdrawInvariant invariant.
 
-	app.mm.SetOrderBeginBlockers(distr.ModuleName, slashing.ModuleName, nameservice.ModuleName)
-	app.mm.SetOrderEndBlockers(staking.ModuleName)
+	app.mm.SetOrderBeginBlockers(upgrade.ModuleName, distr.ModuleName, slashing.ModuleName, auction.ModuleName)
+	app.mm.SetOrderEndBlockers(nameservice.ModuleName, crisis.ModuleName, gov.ModuleName, staking.ModuleName)
 
 	// Sets the order of Genesis - Order matters, genutil is to always come last
 	// NOTE: The genutils module must occur after staking so that pools are
 	// properly initialized with tokens from genesis accounts.
+	// auth goes first, so the genesis accounts keep their account numbers on import and the module
+	// accounts created by distr and staking are numbered after them
 	app.mm.SetOrderInitGenesis(
+		auth.ModuleName,
 		distr.ModuleName,
 		staking.ModuleName,
-		auth.ModuleName,
 		bank.ModuleName,
 		slashing.ModuleName,
+		gov.ModuleName,
 		// TODO: Add your module(s)
 		nameservice.ModuleName,
+		auction.ModuleName,
 		supply.ModuleName,
+		crisis.ModuleName,
 		genutil.ModuleName,
 	)
 
+	// the crisis module asserts the invariants of every module each invCheckPeriod blocks
+	app.mm.RegisterInvariants(&app.crisisKeeper)
+
 	// register all module routes and module queriers
 	app.mm.RegisterRoutes(app.Router(), app.QueryRouter())
 
+	// create the simulation manager and define the order of the modules for deterministic simulations
+	//
+	// NOTE: this is not required apps that don't use the simulator for fuzz testing
+	// transactions
+	app.sm = module.NewSimulationManager(
+		auth.NewAppModule(app.accountKeeper),
+		bank.NewAppModule(app.bankKeeper, app.accountKeeper),
+		supply.NewAppModule(app.supplyKeeper, app.accountKeeper),
+		gov.NewAppModule(app.govKeeper, app.accountKeeper, app.supplyKeeper),
+		staking.NewAppModule(app.stakingKeeper, app.accountKeeper, app.supplyKeeper),
+		distrSimulation{distr.NewAppModule(app.distrKeeper, app.accountKeeper, app.supplyKeeper, app.stakingKeeper)},
+		slashing.NewAppModule(app.slashingKeeper, app.accountKeeper, app.stakingKeeper),
+		params.NewAppModule(), // NOTE: only used for simulation to generate randomized param change proposals
+		nameservice.NewAppModule(app.nsKeeper, app.bankKeeper, app.accountKeeper, app.auctionKeeper),
+		auction.NewAppModule(app.auctionKeeper),
+	)
+
+	app.sm.RegisterStoreDecoders()
+
 	// The initChainer handles translating the genesis.json file into initial state for the network
 	app.SetInitChainer(app.InitChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
@@ -326,6 +463,11 @@ func (app *NewApp) ModuleAccountAddrs() map[string]bool {
 	return modAccAddrs
 }
 
+// SetSettleAuctionsOnExport sets whether a zero-height export settles the open auctions
+func (app *NewApp) SetSettleAuctionsOnExport(settle bool) {
+	app.settleAuctionsOnExport = settle
+}
+
 // Codec returns the application's sealed codec.
 func (app *NewApp) Codec() *codec.Codec {
 	return app.cdc
@@ -344,3 +486,14 @@ func GetMaccPerms() map[string][]string {
 	}
 	return modAccPerms
 }
+
+// distrSimulation simulates the distribution module without community pool spend proposals, the
+// gov router has no route for them
+type distrSimulation struct {
+	distr.AppModule
+}
+
+// ProposalContents returns no proposals
+func (distrSimulation) ProposalContents(_ module.SimulationState) []simulation.WeightedProposalContent {
+	return nil
+}
@@ -11,6 +11,9 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/staking"
+
+	"github.com/rune/baseapp/x/auction"
+	"github.com/rune/baseapp/x/nameservice"
 )
 
 // ExportAppStateAndValidators exports the state of the application for a genesis
@@ -166,4 +169,17 @@ func (app *NewApp) prepForZeroHeightGenesis(ctx sdk.Context, jailWhiteList []str
 			return false
 		},
 	)
+
+	/* Handle auction state. */
+
+	// settle the open auctions, or carry them over with the blocks they have left
+	if app.settleAuctionsOnExport {
+		auction.SettleAuctions(ctx, app.auctionKeeper)
+	}
+	auction.RebaseHeights(ctx, app.auctionKeeper, height)
+
+	/* Handle nameservice state. */
+
+	// names keep the blocks left until their rent is due
+	nameservice.RebaseRentHeights(ctx, app.nsKeeper, height)
 }
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rune/baseapp/x/auction"
+	"github.com/rune/baseapp/x/nameservice"
+)
+
+func TestNameserviceAuctionGenesisRoundTrip(t *testing.T) {
+	header := abci.Header{Height: 20, Time: time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)}
+	app := NewInitApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{}, 0)
+	ctx := app.NewContext(true, header)
+
+	seller := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	bidder1 := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	bidder2 := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	coins := func(amt int64) sdk.Coins { return sdk.NewCoins(sdk.NewInt64Coin("nametoken", amt)) }
+
+	app.nsKeeper.SetParams(ctx, nameservice.DefaultParams())
+	app.nsKeeper.SetConsensusVersion(ctx, nameservice.ConsensusVersion)
+	app.auctionKeeper.SetParams(ctx, auction.DefaultParams())
+	for _, name := range []string{"english", "sealed", "timed", "settled", "bundle", "x", "y", "idle"} {
+		app.nsKeeper.SetWhois(ctx, name, nameservice.Whois{Value: name + ".value", Owner: seller, Price: coins(5)})
+	}
+
+	start := func(a auction.Auction) auction.Auction {
+		a.Asset, a.Owner, a.ReservePrice = nameservice.AssetRoute, seller, coins(10)
+		require.NoError(t, app.auctionKeeper.StartAuction(ctx, a))
+		return app.auctionKeeper.GetAuction(ctx, a.Lot)
+	}
+	start(auction.Auction{Lot: "english", Type: auction.AuctionTypeEnglish, BuyNowPrice: coins(100)})
+	app.auctionKeeper.SetBid(ctx, "english", coins(11), bidder1)
+	app.auctionKeeper.SetProxyBid(ctx, "english", coins(12), bidder2, coins(30))
+	start(auction.Auction{Lot: "sealed", Type: auction.AuctionTypeSealed})
+	app.auctionKeeper.SetSealedBid(ctx, auction.SealedBid{Lot: "sealed", Bidder: bidder1, Hash: []byte{1, 2, 3}, Deposit: coins(40)})
+	app.auctionKeeper.SetSealedBid(ctx, auction.SealedBid{Lot: "sealed", Bidder: bidder2, Hash: []byte{4, 5, 6},
+		Deposit: coins(20), Revealed: true, BidPrice: coins(15)})
+	start(auction.Auction{Lot: "timed", Type: auction.AuctionTypeEnglish, EndTime: header.Time.Add(time.Hour)})
+	start(auction.Auction{Lot: "bundle", Type: auction.AuctionTypeEnglish, Items: []string{"bundle", "x", "y"},
+		Private: true, Allowlist: []sdk.AccAddress{bidder1}})
+	settled := start(auction.Auction{Lot: "settled", Type: auction.AuctionTypeEnglish})
+	app.auctionKeeper.ArchiveAuction(ctx, settled, auction.OutcomeNoBids, nil, nil)
+	app.nsKeeper.UnlockName(ctx, "settled")
+
+	// genesis travels as JSON
+	var nsGenesis nameservice.GenesisState
+	app.cdc.MustUnmarshalJSON(app.cdc.MustMarshalJSON(nameservice.ExportGenesis(ctx, app.nsKeeper)), &nsGenesis)
+	var auctionGenesis auction.GenesisState
+	app.cdc.MustUnmarshalJSON(app.cdc.MustMarshalJSON(auction.ExportGenesis(ctx, app.auctionKeeper)), &auctionGenesis)
+	require.Len(t, nsGenesis.WhoisRecords, 8)
+	require.Len(t, nsGenesis.NameLocks, 6)
+	require.Len(t, auctionGenesis.AuctionRecords, 4)
+	require.Len(t, auctionGenesis.Bids, 2)
+	require.Len(t, auctionGenesis.SealedBids, 2)
+	require.Len(t, auctionGenesis.SettledAuctions, 1)
+	require.NoError(t, nameservice.ValidateGenesis(nsGenesis))
+	require.NoError(t, auction.ValidateGenesis(auctionGenesis))
+
+	newApp := NewInitApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{}, 0)
+	newCtx := newApp.NewContext(true, header)
+	nameservice.InitGenesis(newCtx, newApp.nsKeeper, nsGenesis)
+	auction.InitGenesis(newCtx, newApp.auctionKeeper, auctionGenesis)
+
+	for _, key := range []string{nameservice.StoreKey, auction.StoreKey} {
+		storeA := ctx.KVStore(app.keys[key])
+		storeB := newCtx.KVStore(newApp.keys[key])
+		kvAs, kvBs := sdk.DiffKVStores(storeA, storeB, nil)
+		require.Empty(t, kvAs, "%s store differs after import", key)
+		require.Empty(t, kvBs, "%s store differs after import", key)
+	}
+}
+
+func TestRebaseHeightsSettlesOverdueAuctions(t *testing.T) {
+	header := abci.Header{Height: 200, Time: time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)}
+	app := NewInitApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{}, 0)
+	ctx := app.NewContext(true, header)
+
+	seller := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	coins := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10))
+	app.nsKeeper.SetParams(ctx, nameservice.DefaultParams())
+	app.auctionKeeper.SetParams(ctx, auction.DefaultParams())
+	for _, name := range []string{"overdue", "open"} {
+		app.nsKeeper.SetWhois(ctx, name, nameservice.Whois{Value: name, Owner: seller, Price: coins})
+		app.nsKeeper.LockName(ctx, name, name)
+	}
+	// the settlement cap left an auction past its deadline in the store
+	app.auctionKeeper.SetAuction(ctx, "overdue", auction.Auction{Lot: "overdue", Asset: nameservice.AssetRoute,
+		Owner: seller, ReservePrice: coins, Type: auction.AuctionTypeEnglish, Deadline: 150}, true)
+	app.auctionKeeper.SetAuction(ctx, "open", auction.Auction{Lot: "open", Asset: nameservice.AssetRoute,
+		Owner: seller, ReservePrice: coins, Type: auction.AuctionTypeEnglish, Deadline: 250}, true)
+
+	auction.RebaseHeights(ctx, app.auctionKeeper, header.Height)
+
+	require.Equal(t, int64(0), app.auctionKeeper.GetAuction(ctx, "overdue").Deadline)
+	require.Equal(t, int64(50), app.auctionKeeper.GetAuction(ctx, "open").Deadline)
+	require.NoError(t, auction.ValidateGenesis(auction.ExportGenesis(ctx, app.auctionKeeper)))
+
+	due := app.auctionKeeper.GetDueAuctions(ctx, 1, header.Time, 10)
+	require.Len(t, due, 1)
+	require.Equal(t, "overdue", due[0].Lot)
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+	"github.com/rune/baseapp/x/auction"
+	"github.com/rune/baseapp/x/nameservice"
+)
+
+// baselineAuction is the auction exactly as the first release encoded it
+type baselineAuction struct {
+	Lot          string         `json:"lot"`
+	Owner        sdk.AccAddress `json:"owner"`
+	ReservePrice sdk.Coins      `json:"reserve_price"`
+	Bidder       sdk.AccAddress `json:"bidder"`
+	BidPrice     sdk.Coins      `json:"bid_price"`
+	Deadline     int64          `json:"deadline"`
+}
+
+func TestMigrateBaselineAuctions(t *testing.T) {
+	app := NewInitApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{}, 0)
+	ctx := app.NewContext(true, abci.Header{Height: 50})
+
+	owner := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	bidder := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	buyer := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	coins := func(amt int64) sdk.Coins { return sdk.NewCoins(sdk.NewInt64Coin("nametoken", amt)) }
+
+	acc := app.accountKeeper.NewAccountWithAddress(ctx, bidder)
+	require.NoError(t, acc.SetCoins(coins(100)))
+	app.accountKeeper.SetAccount(ctx, acc)
+
+	// the baseline kept names at the store root and auctions under "Auction:", both in the
+	// nameservice store
+	store := ctx.KVStore(app.keys[nameservice.StoreKey])
+	setName := func(name string, owner sdk.AccAddress) {
+		whois := nameservice.Whois{Value: "value", Owner: owner, Price: coins(1)}
+		store.Set([]byte(name), app.cdc.MustMarshalBinaryBare(whois))
+	}
+	setAuction := func(a baselineAuction) {
+		store.Set([]byte("Auction:"+a.Lot), app.cdc.MustMarshalBinaryBare(a))
+	}
+	setName("sold", owner)
+	setName("unsold", owner)
+	setName("resold", buyer)
+	// names that look like the keys of later layouts are still names
+	lookalikes := []string{"Bid:", "Auction:z", "AuctionQueue:x", "SettledAuctionCount", "Lock:resold"}
+	for _, name := range lookalikes {
+		setName(name, buyer)
+	}
+	setAuction(baselineAuction{Lot: "sold", Owner: owner, ReservePrice: coins(10), Bidder: bidder, BidPrice: coins(20), Deadline: 120})
+	setAuction(baselineAuction{Lot: "unsold", Owner: owner, ReservePrice: coins(10), Deadline: 130})
+	// the baseline did not lock names, this one was bought while its auction ran
+	setAuction(baselineAuction{Lot: "resold", Owner: owner, ReservePrice: coins(10), Bidder: bidder, BidPrice: coins(30), Deadline: 120})
+
+	app.upgradeKeeper.ApplyUpgrade(ctx, upgrade.Plan{Name: upgradeNameserviceV2, Height: 50})
+
+	require.Equal(t, nameservice.ConsensusVersion, app.nsKeeper.GetConsensusVersion(ctx))
+	require.Equal(t, auction.DefaultParams(), app.auctionKeeper.GetParams(ctx))
+	require.Equal(t, buyer, app.nsKeeper.GetOwner(ctx, "resold"))
+	for _, name := range lookalikes {
+		require.Equal(t, buyer, app.nsKeeper.GetOwner(ctx, name), name)
+		require.False(t, app.nsKeeper.IsNameLocked(ctx, name), name)
+	}
+	require.False(t, app.nsKeeper.IsNameLocked(ctx, "resold"))
+	require.False(t, app.auctionKeeper.HasAuction(ctx, "resold"))
+	settled, ok := app.auctionKeeper.GetSettledAuction(ctx, 0)
+	require.True(t, ok)
+	require.Equal(t, auction.OutcomeVoided, settled.Outcome)
+
+	for _, lot := range []string{"sold", "unsold"} {
+		a := app.auctionKeeper.GetAuction(ctx, lot)
+		require.Equal(t, auction.AuctionTypeEnglish, a.Type)
+		require.Equal(t, nameservice.AssetRoute, a.Asset)
+		require.Equal(t, lot, app.nsKeeper.GetNameLock(ctx, lot))
+	}
+	// the names held values before the rent queue, their rent period starts at the upgrade
+	require.Equal(t, 50+nameservice.DefaultParams().RentPeriod, app.nsKeeper.GetWhois(ctx, "sold").RentDue)
+	require.Len(t, app.auctionKeeper.GetBids(ctx, "sold"), 1)
+	require.Empty(t, app.auctionKeeper.GetBids(ctx, "unsold"))
+	for _, invariant := range []sdk.Invariant{nameservice.AllInvariants(app.nsKeeper), auction.AllInvariants(app.auctionKeeper)} {
+		msg, broken := invariant(ctx)
+		require.False(t, broken, msg)
+	}
+
+	// the auctions settle from the deadline queue once their deadline has passed
+	require.Empty(t, app.auctionKeeper.GetDueAuctions(ctx, 120, ctx.BlockTime(), 10))
+	ctx = ctx.WithBlockHeight(131)
+	auction.BeginBlocker(ctx, abci.RequestBeginBlock{Header: abci.Header{Height: 131}}, app.auctionKeeper)
+
+	require.Equal(t, bidder, app.nsKeeper.GetOwner(ctx, "sold"))
+	require.Equal(t, coins(20), app.bankKeeper.GetCoins(ctx, owner))
+	require.Equal(t, coins(80), app.bankKeeper.GetCoins(ctx, bidder))
+	require.Equal(t, owner, app.nsKeeper.GetOwner(ctx, "unsold"))
+	for _, lot := range []string{"sold", "unsold"} {
+		require.False(t, app.auctionKeeper.HasAuction(ctx, lot))
+		require.False(t, app.nsKeeper.IsNameLocked(ctx, lot))
+	}
+}
@@ -0,0 +1,77 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rune/baseapp/x/nameservice"
+)
+
+func TestCollectRentFromQueue(t *testing.T) {
+	app := NewInitApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{}, 0)
+	ctx := app.NewContext(true, abci.Header{Height: 5})
+
+	owner := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	poor := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	coins := func(amt int64) sdk.Coins { return sdk.NewCoins(sdk.NewInt64Coin("nametoken", amt)) }
+	acc := app.accountKeeper.NewAccountWithAddress(ctx, owner)
+	require.NoError(t, acc.SetCoins(coins(100)))
+	app.accountKeeper.SetAccount(ctx, acc)
+
+	params := nameservice.DefaultParams()
+	params.RentPerByte, params.RentPeriod, params.MaxRentCollectionsPerBlock = coins(1), 10, 2
+	app.nsKeeper.SetParams(ctx, params)
+	for _, name := range []string{"a", "b", "c"} {
+		app.nsKeeper.SetWhois(ctx, name, nameservice.Whois{Value: "12345", Owner: owner, Price: coins(1)})
+	}
+	app.nsKeeper.SetWhois(ctx, "poor", nameservice.Whois{Value: "12345", Owner: poor, Price: coins(1)})
+	app.nsKeeper.SetWhois(ctx, "empty", nameservice.Whois{Owner: owner, Price: coins(1)})
+
+	// the rent clock starts when the value is written
+	ctx = ctx.WithBlockHeight(12)
+	require.NoError(t, app.nsKeeper.SetOwner(ctx, "late", owner))
+	app.nsKeeper.SetName(ctx, "late", "12345")
+	app.nsKeeper.SetPrice(ctx, "a", coins(2))
+	require.Equal(t, int64(15), app.nsKeeper.GetWhois(ctx, "a").RentDue)
+	require.Equal(t, int64(22), app.nsKeeper.GetWhois(ctx, "late").RentDue)
+	require.Equal(t, int64(0), app.nsKeeper.GetWhois(ctx, "empty").RentDue)
+
+	ctx = ctx.WithBlockHeight(14)
+	app.nsKeeper.CollectRent(ctx)
+	require.Equal(t, coins(100), app.bankKeeper.GetCoins(ctx, owner))
+
+	// the cap leaves the rest of the due names to the next blocks
+	ctx = ctx.WithBlockHeight(15)
+	app.nsKeeper.CollectRent(ctx)
+	require.Equal(t, coins(90), app.bankKeeper.GetCoins(ctx, owner))
+	require.Len(t, app.nsKeeper.GetDueRents(ctx, 15, 10), 2)
+
+	ctx = ctx.WithBlockHeight(16)
+	app.nsKeeper.CollectRent(ctx)
+	require.Equal(t, coins(85), app.bankKeeper.GetCoins(ctx, owner))
+	require.Equal(t, "", app.nsKeeper.ResolveName(ctx, "poor"))
+	require.Equal(t, int64(0), app.nsKeeper.GetWhois(ctx, "poor").RentDue)
+	require.Empty(t, app.nsKeeper.GetDueRents(ctx, 16, 10))
+	for _, name := range []string{"a", "b"} {
+		require.Equal(t, int64(25), app.nsKeeper.GetWhois(ctx, name).RentDue)
+	}
+	require.Equal(t, int64(26), app.nsKeeper.GetWhois(ctx, "c").RentDue)
+
+	// a new value starts a new period
+	ctx = ctx.WithBlockHeight(18)
+	app.nsKeeper.SetName(ctx, "a", "123")
+	require.Equal(t, []string{"late", "b", "c", "a"}, app.nsKeeper.GetDueRents(ctx, 30, 10))
+
+	// a zero-height export keeps the blocks left until the rent is due
+	ctx = ctx.WithBlockHeight(24)
+	nameservice.RebaseRentHeights(ctx, app.nsKeeper, 24)
+	require.Equal(t, int64(1), app.nsKeeper.GetWhois(ctx, "late").RentDue)
+	require.Equal(t, int64(4), app.nsKeeper.GetWhois(ctx, "a").RentDue)
+	require.NoError(t, nameservice.ValidateGenesis(nameservice.ExportGenesis(ctx, app.nsKeeper)))
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/types/module"
+
+	"github.com/rune/baseapp/x/auction"
+	"github.com/rune/baseapp/x/nameservice"
+)
+
+// ValidateGenesisCmd returns the validate-genesis cobra Command. Every module section is
+// validated, and the nameservice section is checked against the name auctions of the auction
+// section, reporting every problem found in one go.
+func ValidateGenesisCmd(ctx *server.Context, cdc *codec.Codec, mbm module.BasicManager) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate-genesis [file]",
+		Args:  cobra.RangeArgs(0, 1),
+		Short: "validates the genesis file at the default location or at the location passed as an arg",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+
+			// Load default if passed no args, otherwise load passed file
+			genesis := ctx.Config.GenesisFile()
+			if len(args) != 0 {
+				genesis = args[0]
+			}
+
+			fmt.Fprintf(os.Stderr, "validating genesis file at %s\n", genesis)
+
+			var genDoc *tmtypes.GenesisDoc
+			if genDoc, err = tmtypes.GenesisDocFromFile(genesis); err != nil {
+				return fmt.Errorf("error loading genesis doc from %s: %s", genesis, err.Error())
+			}
+
+			var genState map[string]json.RawMessage
+			if err = cdc.UnmarshalJSON(genDoc.AppState, &genState); err != nil {
+				return fmt.Errorf("error unmarshalling genesis doc %s: %s", genesis, err.Error())
+			}
+
+			if err = validateAppState(mbm, genState, genDoc.GenesisTime); err != nil {
+				return fmt.Errorf("error validating genesis file %s: %s", genesis, err.Error())
+			}
+
+			fmt.Printf("File at %s is a valid genesis file\n", genesis)
+			return nil
+		},
+	}
+}
+
+// validateAppState validates the section of every module, in module name order, and checks the
+// names against the name auctions. Unlike mbm.ValidateGenesis it does not stop at the first
+// invalid section, the returned error lists the problems of all of them.
+func validateAppState(mbm module.BasicManager, genState map[string]json.RawMessage, genesisTime time.Time) error {
+	names := make([]string, 0, len(mbm))
+	for name := range mbm {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+	for _, name := range names {
+		if err := mbm[name].ValidateGenesis(genState[name]); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", name, err))
+		}
+	}
+
+	// a section that does not decode was reported by its module
+	var nsState nameservice.GenesisState
+	var auctionState auction.GenesisState
+	if nameservice.ModuleCdc.UnmarshalJSON(genState[nameservice.ModuleName], &nsState) == nil &&
+		auction.ModuleCdc.UnmarshalJSON(genState[auction.ModuleName], &auctionState) == nil {
+		if err := validateNameAuctions(nsState, auctionState, genesisTime); err != nil {
+			problems = append(problems, fmt.Sprintf("name auctions: %s", err))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d invalid sections:\n%s", len(problems), strings.Join(problems, "\n"))
+}
+
+// validateNameAuctions checks the auctions against the names they sell. Every name auction must
+// sell names that are registered to its owner, or unregistered for a chain-run auction, and
+// locked for its lot, and every lock must belong to such an auction. Every timed auction must
+// end after genesis. Every problem is reported with the record and field it was found at.
+func validateNameAuctions(data nameservice.GenesisState, auctionData auction.GenesisState, genesisTime time.Time) error {
+	var problems []string
+	addf := func(field string, format string, args ...interface{}) {
+		problems = append(problems, field+": "+fmt.Sprintf(format, args...))
+	}
+
+	whois := make(map[string]nameservice.WhoisRecord, len(data.WhoisRecords))
+	for _, record := range data.WhoisRecords {
+		whois[record.Name] = record
+	}
+	locks := make(map[string]string, len(data.NameLocks))
+	for _, lock := range data.NameLocks {
+		locks[lock.Name] = lock.Lot
+	}
+
+	soldBy := make(map[string]int)
+	for i, record := range auctionData.AuctionRecords {
+		field := fmt.Sprintf("auction_records[%d]", i)
+		switch {
+		case record.IsTimed() && record.IsSealed() && !genesisTime.IsZero() && record.RevealEndTime.Before(genesisTime):
+			addf(field+".reveal_end_time", "%s is before the genesis time", record.RevealEndTime)
+		case record.IsTimed() && !record.IsSealed() && !genesisTime.IsZero() && record.EndTime.Before(genesisTime):
+			addf(field+".end_time", "%s is before the genesis time", record.EndTime)
+		}
+		if record.Asset != nameservice.AssetRoute {
+			continue
+		}
+		for _, name := range record.LotItems() {
+			if j, ok := soldBy[name]; ok {
+				addf(field+".items", "name %s is also sold by auction_records[%d]", name, j)
+				continue
+			}
+			soldBy[name] = i
+			owned, registered := whois[name]
+			switch {
+			case record.IsInitial() && registered:
+				addf(field+".items", "name %s of a chain-run auction is registered", name)
+			case !record.IsInitial() && !registered:
+				addf(field+".items", "name %s has no whois record", name)
+			case !record.IsInitial() && !owned.Owner.Equals(record.Owner):
+				addf(field+".owner", "%s does not own name %s", record.Owner, name)
+			}
+			if lot, ok := locks[name]; !ok || lot != record.Lot {
+				addf(field+".items", "name %s is not locked for lot %s", name, record.Lot)
+			}
+		}
+	}
+
+	for i, lock := range data.NameLocks {
+		if j, ok := soldBy[lock.Name]; !ok || auctionData.AuctionRecords[j].Lot != lock.Lot {
+			addf(fmt.Sprintf("name_locks[%d].lot", i), "no name auction of lot %s sells name %s", lock.Lot, lock.Name)
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d problems in the name auctions:\n\t%s", len(problems), strings.Join(problems, "\n\t"))
+}
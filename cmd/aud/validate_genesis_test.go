@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rune/baseapp/app"
+	"github.com/rune/baseapp/x/auction"
+	"github.com/rune/baseapp/x/nameservice"
+)
+
+func TestValidateAppStateReportsEverySection(t *testing.T) {
+	owner := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 10))
+	genState := app.ModuleBasics.DefaultGenesis()
+	require.NoError(t, validateAppState(app.ModuleBasics, genState, time.Time{}))
+
+	// a name without an owner
+	nsState := nameservice.DefaultGenesisState()
+	nsState.WhoisRecords = []nameservice.WhoisRecord{
+		{Name: "sold", Owner: owner, Price: price},
+		{Name: "orphan", Price: price},
+	}
+	genState[nameservice.ModuleName] = nameservice.ModuleCdc.MustMarshalJSON(nsState)
+	// a bid of an unknown lot, and an auction of a name that is not locked for it
+	auctionState := auction.DefaultGenesisState()
+	auctionState.AuctionRecords = []auction.Auction{{Lot: "sold", Asset: nameservice.AssetRoute, Owner: owner,
+		ReservePrice: price, Type: auction.AuctionTypeEnglish, Deadline: 100}}
+	auctionState.Bids = []auction.Bid{{Lot: "unknown", Bidder: owner, Price: price}}
+	genState[auction.ModuleName] = auction.ModuleCdc.MustMarshalJSON(auctionState)
+
+	err := validateAppState(app.ModuleBasics, genState, time.Time{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "3 invalid sections")
+	require.Contains(t, err.Error(), "auction: ")
+	require.Contains(t, err.Error(), "nameservice: ")
+	require.Contains(t, err.Error(), "name auctions: ")
+}
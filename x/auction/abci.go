@@ -0,0 +1,165 @@
+package auction
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rune/baseapp/x/auction/internal/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// BeginBlocker settles the auctions whose deadline has passed. Only the due part of the
+// deadline queues is read, auctions over the per block cap are settled in the next blocks.
+func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k Keeper) {
+	for _, auction := range k.GetDueAuctions(ctx, req.Header.Height, req.Header.Time, int(k.GetParams(ctx).MaxSettlementsPerBlock)) {
+		if auction.IsSealed() {
+			settleSealedAuction(ctx, k, auction, true)
+		} else {
+			settleAuction(ctx, k, auction)
+		}
+	}
+}
+
+// settleAuction hands an english auction to its highest bidder. An escrowed high bid is paid out
+// of escrow, a proxy bidder gets back what is left of its maximum. Otherwise the bid book is walked down from the top until a bidder can pay, every
+// bidder only with its latest bid. Dutch auctions only get here when they expire unsold.
+func settleAuction(ctx sdk.Context, k Keeper, auction types.Auction) {
+	if held := auction.HeldEscrow(); !held.Empty() {
+		if auction.Bidder.Equals(auction.Owner) {
+			mustReleaseCoins(ctx, k, auction.Bidder, held)
+			if !relistAuction(ctx, k, auction) {
+				closeAuction(ctx, k, auction, types.OutcomeNoBids, nil, nil)
+			}
+			return
+		}
+		mustReleaseProceeds(ctx, k, auction, auction.BidPrice)
+		mustReleaseCoins(ctx, k, auction.Bidder, held.Sub(auction.BidPrice))
+		transferLot(ctx, k, auction, auction.Bidder, auction.BidPrice)
+		return
+	}
+
+	bids := k.GetBids(ctx, auction.Lot)
+	tried := make(map[string]bool)
+	for i := len(bids) - 1; i >= 0; i-- {
+		bid := bids[i]
+		if bid.Bidder.Equals(auction.Owner) || tried[bid.Bidder.String()] {
+			continue
+		}
+		tried[bid.Bidder.String()] = true
+		if err := k.PayProceeds(ctx, auction, bid.Bidder, bid.Price); err == nil {
+			transferLot(ctx, k, auction, bid.Bidder, bid.Price)
+			return
+		}
+	}
+	if len(tried) == 0 {
+		if !relistAuction(ctx, k, auction) {
+			closeAuction(ctx, k, auction, types.OutcomeNoBids, nil, nil)
+		}
+		return
+	}
+	// nobody won, the archived auction still shows the high bid that was not paid
+	closeAuction(ctx, k, auction, types.OutcomeInsufficientFunds, nil, nil)
+}
+
+// settleSealedAuction awards the lot to the highest revealed bid at the second-highest price,
+// or at the reserve price when there is a single valid bid. Revealed losing bids get their
+// deposit back, deposits of bids that were never revealed are forfeited to the seller unless
+// forfeit is false.
+func settleSealedAuction(ctx sdk.Context, k Keeper, auction types.Auction, forfeit bool) {
+	bids := k.GetSealedBids(ctx, auction.Lot)
+
+	var winner *types.SealedBid
+	for i := range bids {
+		bid := &bids[i]
+		if !bid.Revealed || !bid.BidPrice.IsAllGTE(auction.ReservePrice) {
+			continue
+		}
+		if winner == nil || bid.BidPrice.IsAllGT(winner.BidPrice) {
+			winner = bid
+		}
+	}
+	// the second price only counts bids the winner outbids in every denom, so it never exceeds the winning bid
+	price := auction.ReservePrice
+	for _, bid := range bids {
+		if winner == nil || !bid.Revealed || bid.Bidder.Equals(winner.Bidder) {
+			continue
+		}
+		if bid.BidPrice.IsAllGT(price) && winner.BidPrice.IsAllGTE(bid.BidPrice) {
+			price = bid.BidPrice
+		}
+	}
+
+	for _, bid := range bids {
+		switch {
+		case winner != nil && bid.Bidder.Equals(winner.Bidder):
+			mustReleaseProceeds(ctx, k, auction, price)
+			mustReleaseCoins(ctx, k, bid.Bidder, bid.Deposit.Sub(price))
+		case bid.Revealed || !forfeit:
+			mustReleaseCoins(ctx, k, bid.Bidder, bid.Deposit)
+		default:
+			mustReleaseProceeds(ctx, k, auction, bid.Deposit)
+		}
+		k.DeleteSealedBid(ctx, auction.Lot, bid.Bidder)
+	}
+	if winner == nil {
+		if !relistAuction(ctx, k, auction) {
+			closeAuction(ctx, k, auction, types.OutcomeNoBids, nil, nil)
+		}
+		return
+	}
+	transferLot(ctx, k, auction, winner.Bidder, price)
+}
+
+// relistAuction starts another round of an auction that ended without bids at a lower reserve
+// price. The lot stays locked and the bond stays posted. It reports false when the auction is
+// not relisted.
+func relistAuction(ctx sdk.Context, k Keeper, auction types.Auction) bool {
+	reserve, ok := auction.NextReserve()
+	if !ok {
+		return false
+	}
+	auction.ReservePrice = reserve
+	auction.Relists--
+	auction.Bidder, auction.BidPrice, auction.ProxyMax = nil, nil, nil
+	auction.Schedule(k.GetParams(ctx), ctx.BlockHeight(), ctx.BlockTime())
+	k.SetAuction(ctx, auction.Lot, auction, false)
+	return true
+}
+
+// transferLot hands a sold lot to the winner through its asset. The lot was locked when the
+// auction started, so a failing transfer means the asset no longer holds it.
+func transferLot(ctx sdk.Context, k Keeper, auction types.Auction, winner sdk.AccAddress, price sdk.Coins) {
+	asset := k.Router().GetRoute(auction.Asset)
+	if err := asset.Transfer(ctx, auction.LotItems(), auction.Owner, winner, price); err != nil {
+		panic(err)
+	}
+	archiveAuction(ctx, k, auction, types.OutcomeSold, winner, price)
+}
+
+// closeAuction gives an unsold lot back to its owner and archives the auction
+func closeAuction(ctx sdk.Context, k Keeper, auction types.Auction, outcome types.AuctionOutcome,
+	winner sdk.AccAddress, price sdk.Coins) {
+	asset := k.Router().GetRoute(auction.Asset)
+	if err := asset.Unlock(ctx, auction.LotItems(), auction.Owner); err != nil {
+		panic(err)
+	}
+	archiveAuction(ctx, k, auction, outcome, winner, price)
+}
+
+// archiveAuction refunds what is left of the seller bond and moves the auction to the archive
+func archiveAuction(ctx sdk.Context, k Keeper, auction types.Auction, outcome types.AuctionOutcome,
+	winner sdk.AccAddress, price sdk.Coins) {
+	mustReleaseCoins(ctx, k, auction.BondPayer(), auction.Bond)
+	k.ArchiveAuction(ctx, auction, outcome, winner, price)
+}
+
+// mustReleaseCoins pays out escrow, failing means the module account no longer covers what it holds
+func mustReleaseCoins(ctx sdk.Context, k Keeper, to sdk.AccAddress, amt sdk.Coins) {
+	if err := k.ReleaseCoins(ctx, to, amt); err != nil {
+		panic(err)
+	}
+}
+
+func mustReleaseProceeds(ctx sdk.Context, k Keeper, auction types.Auction, amt sdk.Coins) {
+	if err := k.ReleaseProceeds(ctx, auction, amt); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,207 @@
+package auction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// sealedBid commits a sealed bid at height 10 and returns the reveal to send later
+func (input testInput) sealedBid(t *testing.T, lot string, bidder sdk.AccAddress, price, deposit int64) MsgRevealBid {
+	salt := bidder.String()
+	hash := SealedBidHash(lot, bidder, coins(price), salt)
+	_, err := input.handler(input.ctx.WithBlockHeight(10), NewCommitBid(lot, bidder, hash, coins(deposit)))
+	require.NoError(t, err)
+	return NewRevealBid(lot, bidder, coins(price), salt)
+}
+
+func TestSealedAuctionSecondPrice(t *testing.T) {
+	input := createTestInput(t)
+	seller := input.newFundedAddr(t, 1000)
+	_, err := input.handler(input.ctx, NewAuction(testAssetRoute, "lot", seller, coins(10), AuctionTypeSealed))
+	require.NoError(t, err)
+	a := input.k.GetAuction(input.ctx, "lot")
+
+	high, second, low := input.newFundedAddr(t, 1000), input.newFundedAddr(t, 1000), input.newFundedAddr(t, 1000)
+	reveals := []MsgRevealBid{
+		input.sealedBid(t, "lot", high, 50, 60),
+		input.sealedBid(t, "lot", second, 30, 40),
+		input.sealedBid(t, "lot", low, 20, 20),
+	}
+	// reveals are only taken after the commit phase
+	_, err = input.handler(input.ctx.WithBlockHeight(a.Deadline), reveals[0])
+	require.Error(t, err)
+	for _, reveal := range reveals {
+		_, err = input.handler(input.ctx.WithBlockHeight(a.Deadline+1), reveal)
+		require.NoError(t, err)
+	}
+
+	input.beginBlock(a.RevealDeadline, time.Time{})
+	require.True(t, input.k.HasAuction(input.ctx, "lot"))
+	input.beginBlock(a.RevealDeadline+1, time.Time{})
+	require.False(t, input.k.HasAuction(input.ctx, "lot"))
+
+	// the highest bid wins at the second-highest price, the losers get their deposit back
+	require.Equal(t, high, input.asset.owners["lot"])
+	require.Equal(t, int64(1000-30), input.balance(high))
+	require.Equal(t, int64(1000), input.balance(second))
+	require.Equal(t, int64(1000), input.balance(low))
+	require.Equal(t, int64(1000+30), input.balance(seller))
+	require.Zero(t, input.moduleBalance(ModuleName))
+	settled := input.lastSettled(t)
+	require.Equal(t, OutcomeSold, settled.Outcome)
+	require.Equal(t, high, settled.Winner)
+	require.Equal(t, coins(30), settled.Price)
+}
+
+func TestSealedAuctionSingleBidPaysReserve(t *testing.T) {
+	input := createTestInput(t)
+	seller := input.newFundedAddr(t, 1000)
+	_, err := input.handler(input.ctx, NewAuction(testAssetRoute, "lot", seller, coins(10), AuctionTypeSealed))
+	require.NoError(t, err)
+	a := input.k.GetAuction(input.ctx, "lot")
+
+	bidder, under := input.newFundedAddr(t, 1000), input.newFundedAddr(t, 1000)
+	reveals := []MsgRevealBid{input.sealedBid(t, "lot", bidder, 50, 50), input.sealedBid(t, "lot", under, 5, 5)}
+	for _, reveal := range reveals {
+		_, err = input.handler(input.ctx.WithBlockHeight(a.Deadline+1), reveal)
+		require.NoError(t, err)
+	}
+	input.beginBlock(a.RevealDeadline+1, time.Time{})
+
+	// a bid below the reserve is no second price
+	require.Equal(t, bidder, input.asset.owners["lot"])
+	require.Equal(t, int64(1000-10), input.balance(bidder))
+	require.Equal(t, int64(1000), input.balance(under))
+	require.Equal(t, int64(1000+10), input.balance(seller))
+}
+
+func TestSealedAuctionForfeitsUnrevealedDeposits(t *testing.T) {
+	input := createTestInput(t)
+	seller := input.newFundedAddr(t, 1000)
+	_, err := input.handler(input.ctx, NewAuction(testAssetRoute, "lot", seller, coins(10), AuctionTypeSealed))
+	require.NoError(t, err)
+	a := input.k.GetAuction(input.ctx, "lot")
+
+	winner, silent := input.newFundedAddr(t, 1000), input.newFundedAddr(t, 1000)
+	reveal := input.sealedBid(t, "lot", winner, 40, 40)
+	input.sealedBid(t, "lot", silent, 100, 25)
+	_, err = input.handler(input.ctx.WithBlockHeight(a.Deadline+1), reveal)
+	require.NoError(t, err)
+	input.beginBlock(a.RevealDeadline+1, time.Time{})
+
+	// a bid that was never revealed sets no price, its deposit goes to the seller
+	require.Equal(t, winner, input.asset.owners["lot"])
+	require.Equal(t, int64(1000-10), input.balance(winner))
+	require.Equal(t, int64(1000-25), input.balance(silent))
+	require.Equal(t, int64(1000+10+25), input.balance(seller))
+	require.Zero(t, input.moduleBalance(ModuleName))
+	require.Empty(t, input.k.GetSealedBids(input.ctx, "lot"))
+
+	// without any reveal the lot stays with the seller, the deposits are still forfeited
+	_, err = input.handler(input.ctx, NewAuction(testAssetRoute, "unrevealed", seller, coins(10), AuctionTypeSealed))
+	require.NoError(t, err)
+	input.sealedBid(t, "unrevealed", silent, 100, 25)
+	input.beginBlock(a.RevealDeadline+1, time.Time{})
+	require.False(t, input.k.HasAuction(input.ctx, "unrevealed"))
+	require.NotContains(t, input.asset.locked, "unrevealed")
+	require.Nil(t, input.asset.owners["unrevealed"])
+	require.Equal(t, int64(1000-50), input.balance(silent))
+	require.Equal(t, int64(1000+10+50), input.balance(seller))
+	require.Equal(t, OutcomeNoBids, input.lastSettled(t).Outcome)
+}
+
+func TestSettleWalksDownTheBidBook(t *testing.T) {
+	input := createTestInput(t)
+	seller := input.newFundedAddr(t, 1000)
+	_, err := input.handler(input.ctx, NewAuction(testAssetRoute, "lot", seller, coins(10), AuctionTypeEnglish))
+	require.NoError(t, err)
+
+	// bids are not escrowed, the bidders only have to pay at settlement
+	first, second, top := input.newFundedAddr(t, 30), input.newFundedAddr(t, 30), input.newFundedAddr(t, 100)
+	for i, bid := range []MsgBid{
+		NewBid("lot", first, coins(20)),
+		NewBid("lot", second, coins(30)),
+		NewBid("lot", top, coins(40)),
+		NewBid("lot", first, coins(50)),
+	} {
+		_, err = input.handler(input.ctx.WithBlockHeight(int64(10+i)), bid)
+		require.NoError(t, err)
+	}
+	deadline := input.k.GetAuction(input.ctx, "lot").Deadline
+	// the top bidder spends what it bid before the auction ends
+	require.NoError(t, input.bk.SendCoins(input.ctx, top, seller, coins(90)))
+	input.beginBlock(deadline+1, time.Time{})
+
+	// first cannot pay its latest 50 and is not asked again for its 20, top cannot pay 40,
+	// second pays its 30 at last
+	require.Equal(t, second, input.asset.owners["lot"])
+	require.Equal(t, int64(0), input.balance(second))
+	require.Equal(t, int64(30), input.balance(first))
+	require.Equal(t, int64(1000+90+30), input.balance(seller))
+	settled := input.lastSettled(t)
+	require.Equal(t, OutcomeSold, settled.Outcome)
+	require.Equal(t, second, settled.Winner)
+	require.Equal(t, coins(30), settled.Price)
+}
+
+func TestSettleWithoutPayingBidder(t *testing.T) {
+	input := createTestInput(t)
+	seller := input.newFundedAddr(t, 1000)
+	_, err := input.handler(input.ctx, NewAuction(testAssetRoute, "lot", seller, coins(10), AuctionTypeEnglish))
+	require.NoError(t, err)
+	bidder := input.newFundedAddr(t, 5)
+	_, err = input.handler(input.ctx.WithBlockHeight(10), NewBid("lot", bidder, coins(20)))
+	require.NoError(t, err)
+	input.beginBlock(input.k.GetAuction(input.ctx, "lot").Deadline+1, time.Time{})
+
+	require.False(t, input.k.HasAuction(input.ctx, "lot"))
+	require.NotContains(t, input.asset.locked, "lot")
+	require.Nil(t, input.asset.owners["lot"])
+	require.Equal(t, int64(1000), input.balance(seller))
+	settled := input.lastSettled(t)
+	require.Equal(t, OutcomeInsufficientFunds, settled.Outcome)
+	require.Empty(t, settled.Winner)
+	require.Empty(t, settled.Price)
+	require.Equal(t, bidder, settled.Auction.Bidder)
+	require.Equal(t, coins(20), settled.Auction.BidPrice)
+}
+
+func TestRelistWithoutBids(t *testing.T) {
+	input := createTestInput(t)
+	seller := input.newFundedAddr(t, 1000)
+	msg := NewAuction(testAssetRoute, "lot", seller, coins(100), AuctionTypeEnglish)
+	msg.Relists, msg.ReserveStep, msg.FloorPrice = 3, coins(30), coins(50)
+	_, err := input.handler(input.ctx, msg)
+	require.NoError(t, err)
+
+	// the reserve drops by the step but not below the floor, relisting stops at the floor
+	for _, reserve := range []int64{70, 50} {
+		a := input.k.GetAuction(input.ctx, "lot")
+		ctx := input.beginBlock(a.Deadline+1, time.Time{})
+		relisted := input.k.GetAuction(ctx, "lot")
+		require.Equal(t, coins(reserve), relisted.ReservePrice)
+		require.Equal(t, ctx.BlockHeight()+DefaultParams().AuctionPeriod, relisted.Deadline)
+		require.Equal(t, "lot", input.asset.locked["lot"])
+	}
+	input.beginBlock(input.k.GetAuction(input.ctx, "lot").Deadline+1, time.Time{})
+	require.False(t, input.k.HasAuction(input.ctx, "lot"))
+	require.NotContains(t, input.asset.locked, "lot")
+	require.Equal(t, OutcomeNoBids, input.lastSettled(t).Outcome)
+	require.Equal(t, int64(1000), input.balance(seller))
+
+	// a bid on a relisted auction has to beat the lowered reserve
+	msg.Lot = "sold"
+	_, err = input.handler(input.ctx, msg)
+	require.NoError(t, err)
+	ctx := input.beginBlock(input.k.GetAuction(input.ctx, "sold").Deadline+1, time.Time{})
+	bidder := input.newFundedAddr(t, 100)
+	_, err = input.handler(ctx, NewBid("sold", bidder, coins(71)))
+	require.NoError(t, err)
+	input.beginBlock(input.k.GetAuction(input.ctx, "sold").Deadline+1, time.Time{})
+	require.Equal(t, bidder, input.asset.owners["sold"])
+	require.Equal(t, int64(100-71), input.balance(bidder))
+}
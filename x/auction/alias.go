@@ -0,0 +1,71 @@
+package auction
+
+import (
+	"github.com/rune/baseapp/x/auction/internal/keeper"
+	"github.com/rune/baseapp/x/auction/internal/types"
+)
+
+const (
+	ModuleName        = types.ModuleName
+	RouterKey         = types.RouterKey
+	StoreKey          = types.StoreKey
+	CoinsAssetRoute   = types.CoinsAssetRoute
+	DefaultParamspace = types.DefaultParamspace
+
+	AuctionTypeEnglish = types.AuctionTypeEnglish
+	AuctionTypeSealed  = types.AuctionTypeSealed
+	AuctionTypeDutch   = types.AuctionTypeDutch
+
+	OutcomeSold              = types.OutcomeSold
+	OutcomeNoBids            = types.OutcomeNoBids
+	OutcomeInsufficientFunds = types.OutcomeInsufficientFunds
+	OutcomeCancelled         = types.OutcomeCancelled
+	OutcomeVoided            = types.OutcomeVoided
+)
+
+var (
+	RegisterInvariants  = keeper.RegisterInvariants
+	AllInvariants       = keeper.AllInvariants
+	NewKeeper           = keeper.NewKeeper
+	NewQuerier          = keeper.NewQuerier
+	NewCoinsAsset       = keeper.NewCoinsAsset
+	NewAssetRouter      = types.NewAssetRouter
+	NewAuction          = types.NewMsgAuction
+	NewDutchAuction     = types.NewMsgDutchAuction
+	NewBid              = types.NewMsgBid
+	NewBuyNow           = types.NewMsgBuyNow
+	NewProxyBid         = types.NewMsgProxyBid
+	NewCancelAuction    = types.NewMsgCancelAuction
+	NewUpdateAllowlist  = types.NewMsgUpdateAllowlist
+	NewCommitBid        = types.NewMsgCommitBid
+	NewRevealBid        = types.NewMsgRevealBid
+	SealedBidHash       = types.SealedBidHash
+	ModuleCdc           = types.ModuleCdc
+	RegisterCodec       = types.RegisterCodec
+	NewParams           = types.NewParams
+	NewGenesisState     = types.NewGenesisState
+	DefaultGenesisState = types.DefaultGenesisState
+	DefaultParams       = types.DefaultParams
+	ParamKeyTable       = types.ParamKeyTable
+)
+
+type (
+	Keeper             = keeper.Keeper
+	CoinsAsset         = keeper.CoinsAsset
+	Asset              = types.Asset
+	AssetRouter        = types.AssetRouter
+	MsgAuction         = types.MsgAuction
+	MsgBid             = types.MsgBid
+	MsgCommitBid       = types.MsgCommitBid
+	MsgRevealBid       = types.MsgRevealBid
+	MsgCancelAuction   = types.MsgCancelAuction
+	MsgUpdateAllowlist = types.MsgUpdateAllowlist
+	Auction            = types.Auction
+	AuctionType        = types.AuctionType
+	AuctionOutcome     = types.AuctionOutcome
+	SettledAuction     = types.SettledAuction
+	Bid                = types.Bid
+	SealedBid          = types.SealedBid
+	Params             = types.Params
+	GenesisState       = types.GenesisState
+)
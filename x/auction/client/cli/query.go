@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rune/baseapp/x/auction/internal/types"
+)
+
+const (
+	flagSeller = "seller"
+	flagWinner = "winner"
+)
+
+// GetQueryCmd returns the cli query commands for this module
+func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
+	auctionQueryCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      fmt.Sprintf("Querying commands for the %s module", types.ModuleName),
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	auctionQueryCmd.AddCommand(
+		flags.GetCommands(
+			GetCmdAuction(storeKey, cdc),
+			GetCmdAuctions(storeKey, cdc),
+			GetCmdBids(storeKey, cdc),
+			GetCmdSettledAuctions(storeKey, cdc),
+			GetCmdSettledAuction(storeKey, cdc),
+			GetCmdParams(storeKey, cdc),
+		)...,
+	)
+
+	return auctionQueryCmd
+}
+
+func GetCmdAuction(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "auction [lot]",
+		Short: "auction",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			lot := args[0]
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/auction/%s", queryRoute, lot), nil)
+			if err != nil {
+				fmt.Printf("could not resolve auction %s\n", lot)
+				return nil
+			}
+			return cliCtx.PrintOutput(string(res))
+		},
+	}
+}
+
+func GetCmdAuctions(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "auctions",
+		Short: "auctions",
+		//Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			//lot := args[0]
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/auctions", queryRoute), nil)
+			if err != nil {
+				fmt.Printf("could not resolve auctions\n")
+				return nil
+			}
+			var out types.QueryAuctions
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdBids queries the bid book of a running auction
+func GetCmdBids(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "bids [lot]",
+		Short: "Query every bid of an auction, oldest first",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/bids/%s", queryRoute, args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			var out types.QueryResBids
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdSettledAuctions queries a page of the auction archive
+func GetCmdSettledAuctions(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "settled-auctions",
+		Short: "Query completed auctions with optional filters",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params := types.NewQuerySettledAuctionsParams(viper.GetInt(flags.FlagPage), viper.GetInt(flags.FlagLimit), nil, nil)
+			if seller := viper.GetString(flagSeller); len(seller) != 0 {
+				addr, err := sdk.AccAddressFromBech32(seller)
+				if err != nil {
+					return err
+				}
+				params.Seller = addr
+			}
+			if winner := viper.GetString(flagWinner); len(winner) != 0 {
+				addr, err := sdk.AccAddressFromBech32(winner)
+				if err != nil {
+					return err
+				}
+				params.Winner = addr
+			}
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/settled-auctions", queryRoute), bz)
+			if err != nil {
+				return err
+			}
+
+			var out types.QueryResSettledAuctions
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+
+	cmd.Flags().Int(flags.FlagPage, 1, "pagination page of settled auctions to query for")
+	cmd.Flags().Int(flags.FlagLimit, 100, "pagination limit of settled auctions to query for")
+	cmd.Flags().String(flagSeller, "", "(optional) filter by seller address")
+	cmd.Flags().String(flagWinner, "", "(optional) filter by winner address")
+	return cmd
+}
+
+// GetCmdSettledAuction queries a completed auction by its archive id
+func GetCmdSettledAuction(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "settled-auction [id]",
+		Short: "Query a completed auction",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/settled-auction/%s", queryRoute, args[0]), nil)
+			if err != nil {
+				return err
+			}
+
+			var out types.SettledAuction
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdParams queries the auction parameters
+func GetCmdParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "params",
+		Short: "Query the current auction parameters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/params", queryRoute), nil)
+			if err != nil {
+				return err
+			}
+
+			var out types.Params
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
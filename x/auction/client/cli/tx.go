@@ -0,0 +1,300 @@
+package cli
+
+import (
+	"bufio"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/rune/baseapp/x/auction/internal/types"
+)
+
+const (
+	flagAuctionType = "type"
+	flagStartPrice  = "start-price"
+	flagPriceStep   = "price-step"
+	flagBuyNowPrice = "buy-now-price"
+	flagBuyNow      = "buy-now"
+	flagInitial     = "initial"
+	flagRelists     = "relists"
+	flagReserveStep = "reserve-step"
+	flagFloorPrice  = "floor-price"
+	flagAllowlist   = "allowlist"
+	flagAsset       = "asset"
+	flagItems       = "items"
+	flagEndTime     = "end-time"
+	flagAdd         = "add"
+	flagRemove      = "remove"
+	flagMaxPrice    = "max-price"
+)
+
+func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
+	auctionTxCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Auction transaction subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	auctionTxCmd.AddCommand(flags.PostCommands(
+		GetCmdAuctionCreate(cdc),
+		GetCmdBid(cdc),
+		GetCmdCommitBid(cdc),
+		GetCmdRevealBid(cdc),
+		GetCmdCancelAuction(cdc),
+		GetCmdUpdateAllowlist(cdc),
+	)...)
+
+	return auctionTxCmd
+}
+
+func GetCmdAuctionCreate(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "start [lot] [reserve-price]",
+		Short: "auction a lot, by default the name given as lot",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			coins, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			auctionType := types.AuctionType(viper.GetString(flagAuctionType))
+			msg := types.NewMsgAuction(viper.GetString(flagAsset), args[0], cliCtx.GetFromAddress(), coins, auctionType)
+			if auctionType == types.AuctionTypeDutch {
+				startPrice, err := sdk.ParseCoins(viper.GetString(flagStartPrice))
+				if err != nil {
+					return err
+				}
+				step, err := sdk.ParseCoins(viper.GetString(flagPriceStep))
+				if err != nil {
+					return err
+				}
+				msg = types.NewMsgDutchAuction(viper.GetString(flagAsset), args[0], cliCtx.GetFromAddress(), coins, startPrice, step)
+			}
+			msg.Initial = viper.GetBool(flagInitial)
+			if buyNow := viper.GetString(flagBuyNowPrice); len(buyNow) != 0 {
+				msg.BuyNowPrice, err = sdk.ParseCoins(buyNow)
+				if err != nil {
+					return err
+				}
+			}
+			if msg.Relists = viper.GetUint64(flagRelists); msg.Relists > 0 {
+				msg.ReserveStep, err = sdk.ParseCoins(viper.GetString(flagReserveStep))
+				if err != nil {
+					return err
+				}
+				msg.FloorPrice, err = sdk.ParseCoins(viper.GetString(flagFloorPrice))
+				if err != nil {
+					return err
+				}
+			}
+			msg.Items = viper.GetStringSlice(flagItems)
+			if endTime := viper.GetString(flagEndTime); len(endTime) != 0 {
+				end, err := time.Parse(time.RFC3339, endTime)
+				if err != nil {
+					return err
+				}
+				msg.EndTime = end.UTC()
+			}
+			msg.Allowlist, err = parseAddresses(viper.GetStringSlice(flagAllowlist))
+			if err != nil {
+				return err
+			}
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			// return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, msgs)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().String(flagAuctionType, string(types.AuctionTypeEnglish), "auction type, english, sealed or dutch")
+	cmd.Flags().String(flagStartPrice, "", "price a dutch auction starts at")
+	cmd.Flags().String(flagPriceStep, "", "amount a dutch auction price drops every block")
+	cmd.Flags().String(flagBuyNowPrice, "", "(optional) price that ends an english auction immediately")
+	cmd.Flags().String(flagAsset, "name", "asset the lot is made of, name or coins")
+	cmd.Flags().Bool(flagInitial, false, "open a chain-run auction for an unregistered name")
+	cmd.Flags().Uint64(flagRelists, 0, "(optional) number of times the auction is relisted when it ends without bids")
+	cmd.Flags().String(flagReserveStep, "", "amount the reserve price is lowered by on every relisting")
+	cmd.Flags().String(flagFloorPrice, "", "reserve price relisting stops at")
+	cmd.Flags().StringSlice(flagAllowlist, nil, "(optional) comma separated addresses, makes the auction private to them")
+	cmd.Flags().String(flagEndTime, "", "(optional) RFC3339 time an english auction or a sealed-bid commit phase ends at, instead of a number of blocks")
+	cmd.Flags().StringSlice(flagItems, nil, "(optional) comma separated items sold together, e.g. names or coins like 100stake, the lot argument is then the auction ID")
+	return cmd
+}
+
+func GetCmdBid(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:  "bid [lot] [value]",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			coins, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgBid(args[0], cliCtx.GetFromAddress(), coins)
+			if viper.GetBool(flagBuyNow) {
+				msg = types.NewMsgBuyNow(args[0], cliCtx.GetFromAddress(), coins)
+			}
+			if maxPrice := viper.GetString(flagMaxPrice); len(maxPrice) != 0 {
+				msg.MaxPrice, err = sdk.ParseCoins(maxPrice)
+				if err != nil {
+					return err
+				}
+			}
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			// return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, msgs)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().Bool(flagBuyNow, false, "fail unless the bid reaches the buy-now price")
+	cmd.Flags().String(flagMaxPrice, "", "(optional) escrow a maximum and let the bid be raised automatically up to it")
+	return cmd
+}
+
+// GetCmdCommitBid hashes a bid locally and only sends the commitment and the deposit
+func GetCmdCommitBid(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "commit-bid [lot] [value] [salt] [deposit]",
+		Short: "commit a sealed bid, keep the value and salt to reveal it later",
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			price, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				return err
+			}
+			deposit, err := sdk.ParseCoins(args[3])
+			if err != nil {
+				return err
+			}
+
+			hash := types.SealedBidHash(args[0], cliCtx.GetFromAddress(), price, args[2])
+			msg := types.NewMsgCommitBid(args[0], cliCtx.GetFromAddress(), hash, deposit)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdRevealBid(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reveal-bid [lot] [value] [salt]",
+		Short: "reveal a sealed bid committed before",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			price, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRevealBid(args[0], cliCtx.GetFromAddress(), price, args[2])
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdCancelAuction(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel-auction [lot]",
+		Short: "cancel your auction, the seller bond is slashed if bids have arrived",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			msg := types.NewMsgCancelAuction(args[0], cliCtx.GetFromAddress())
+			err := msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+func GetCmdUpdateAllowlist(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-allowlist [lot]",
+		Short: "add or remove bidders of your private auction",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+
+			add, err := parseAddresses(viper.GetStringSlice(flagAdd))
+			if err != nil {
+				return err
+			}
+			remove, err := parseAddresses(viper.GetStringSlice(flagRemove))
+			if err != nil {
+				return err
+			}
+			msg := types.NewMsgUpdateAllowlist(args[0], cliCtx.GetFromAddress(), add, remove)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().StringSlice(flagAdd, nil, "comma separated addresses allowed to bid")
+	cmd.Flags().StringSlice(flagRemove, nil, "comma separated addresses no longer allowed to bid")
+	return cmd
+}
+
+func parseAddresses(bech32s []string) ([]sdk.AccAddress, error) {
+	var addrs []sdk.AccAddress
+	for _, bech32 := range bech32s {
+		addr, err := sdk.AccAddressFromBech32(bech32)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
@@ -0,0 +1,155 @@
+package auction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/rune/baseapp/x/auction/internal/types"
+)
+
+// testAssetRoute is the route of the test asset, its items are only tracked by the asset itself
+const testAssetRoute = "test"
+
+// testAsset sells items that need no other module, chain-run lots included
+type testAsset struct {
+	locked map[string]string
+	owners map[string]sdk.AccAddress
+}
+
+func newTestAsset() *testAsset {
+	return &testAsset{locked: make(map[string]string), owners: make(map[string]sdk.AccAddress)}
+}
+
+func (a *testAsset) Lock(_ sdk.Context, lot string, items []string, _ sdk.AccAddress) error {
+	for _, item := range items {
+		if _, ok := a.locked[item]; ok {
+			return types.ErrAuctionExist
+		}
+	}
+	for _, item := range items {
+		a.locked[item] = lot
+	}
+	return nil
+}
+
+func (a *testAsset) Transfer(_ sdk.Context, items []string, _, winner sdk.AccAddress, _ sdk.Coins) error {
+	for _, item := range items {
+		a.owners[item] = winner
+		delete(a.locked, item)
+	}
+	return nil
+}
+
+func (a *testAsset) Unlock(_ sdk.Context, items []string, _ sdk.AccAddress) error {
+	for _, item := range items {
+		delete(a.locked, item)
+	}
+	return nil
+}
+
+func (a *testAsset) Locked(_ sdk.Context, _ string, _ []string, _ sdk.AccAddress) (sdk.Coins, error) {
+	return nil, nil
+}
+
+// Claims reports whether the lot is an item the asset handed to a winner
+func (a *testAsset) Claims(_ sdk.Context, lot string) bool {
+	return a.owners[lot] != nil
+}
+
+// testInput holds an auction keeper with its handler on a fresh store
+type testInput struct {
+	ctx     sdk.Context
+	ak      auth.AccountKeeper
+	bk      bank.Keeper
+	sk      supply.Keeper
+	k       Keeper
+	asset   *testAsset
+	handler sdk.Handler
+}
+
+func createTestInput(t *testing.T) testInput {
+	keyAuction := sdk.NewKVStoreKey(StoreKey)
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keySupply := sdk.NewKVStoreKey(supply.StoreKey)
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+
+	ms := store.NewCommitMultiStore(dbm.NewMemDB())
+	for _, key := range []sdk.StoreKey{keyAuction, keyAcc, keySupply, keyParams} {
+		ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, nil)
+	}
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, nil)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	RegisterCodec(cdc)
+	auth.RegisterCodec(cdc)
+	supply.RegisterCodec(cdc)
+	sdk.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1}, false, log.NewNopLogger())
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	ak := auth.NewAccountKeeper(cdc, keyAcc, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	bk := bank.NewBaseKeeper(ak, pk.Subspace(bank.DefaultParamspace), map[string]bool{})
+	sk := supply.NewKeeper(cdc, keySupply, ak, bk, map[string][]string{auth.FeeCollectorName: nil, ModuleName: nil})
+
+	asset := newTestAsset()
+	router := NewAssetRouter().
+		AddRoute(testAssetRoute, asset).
+		AddRoute(CoinsAssetRoute, NewCoinsAsset(sk))
+	k := NewKeeper(bk, sk, keyAuction, cdc, auth.FeeCollectorName, router, pk.Subspace(DefaultParamspace))
+	k.SetParams(ctx, DefaultParams())
+	return testInput{ctx: ctx, ak: ak, bk: bk, sk: sk, k: k, asset: asset, handler: NewHandler(k)}
+}
+
+// newFundedAddr returns a new account holding amt nametoken
+func (input testInput) newFundedAddr(t *testing.T, amt int64) sdk.AccAddress {
+	addr := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	_, err := input.bk.AddCoins(input.ctx, addr, coins(amt))
+	require.NoError(t, err)
+	return addr
+}
+
+// balance returns the nametoken held by addr
+func (input testInput) balance(addr sdk.AccAddress) int64 {
+	return input.bk.GetCoins(input.ctx, addr).AmountOf("nametoken").Int64()
+}
+
+// moduleBalance returns the nametoken held by a module account
+func (input testInput) moduleBalance(name string) int64 {
+	return input.balance(input.sk.GetModuleAddress(name))
+}
+
+// beginBlock runs the BeginBlocker of a block at height and block time now and returns its context
+func (input testInput) beginBlock(height int64, now time.Time) sdk.Context {
+	ctx := input.ctx.WithBlockHeight(height).WithBlockTime(now)
+	BeginBlocker(ctx, abci.RequestBeginBlock{Header: abci.Header{Height: height, Time: now}}, input.k)
+	return ctx
+}
+
+// lastSettled returns the auction archived last
+func (input testInput) lastSettled(t *testing.T) SettledAuction {
+	count := input.k.GetSettledAuctionCount(input.ctx)
+	require.NotZero(t, count)
+	settled, ok := input.k.GetSettledAuction(input.ctx, count-1)
+	require.True(t, ok)
+	return settled
+}
+
+func coins(amt int64) sdk.Coins {
+	return sdk.NewCoins(sdk.NewInt64Coin("nametoken", amt))
+}
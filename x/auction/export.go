@@ -0,0 +1,49 @@
+package auction
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SettleAuctions ends every open auction at the current block, e.g. before a zero-height export.
+// The auctions are settled as at their deadline but are not relisted, and sealed bids that were
+// not revealed yet get their deposit back.
+func SettleAuctions(ctx sdk.Context, k Keeper) {
+	for _, auction := range k.GetAuctions(ctx) {
+		auction.Relists = 0
+		if auction.IsSealed() {
+			settleSealedAuction(ctx, k, auction, false)
+		} else {
+			settleAuction(ctx, k, auction)
+		}
+	}
+}
+
+// RebaseHeights moves every height of the auction state back by height for a zero-height export
+// at that height. Open auctions keep the number of blocks they have left, overdue ones settle in
+// the first block. The bid book and the archive keep how many blocks before the export they
+// were recorded.
+func RebaseHeights(ctx sdk.Context, k Keeper, height int64) {
+	for _, auction := range k.GetAuctions(ctx) {
+		for seq, bid := range k.GetBids(ctx, auction.Lot) {
+			bid.Height -= height
+			k.SetBidAt(ctx, uint64(seq), bid)
+		}
+		auction.Rebase(height)
+		auction.ClampDeadlines()
+		k.SetAuction(ctx, auction.Lot, auction, true)
+	}
+
+	var archive []SettledAuction
+	iterator := k.GetSettledAuctionsIterator(ctx)
+	for ; iterator.Valid(); iterator.Next() {
+		var settled SettledAuction
+		ModuleCdc.MustUnmarshalBinaryBare(iterator.Value(), &settled)
+		archive = append(archive, settled)
+	}
+	iterator.Close()
+	for _, settled := range archive {
+		settled.SettledHeight -= height
+		settled.Auction.Rebase(height)
+		k.SetSettledAuction(ctx, settled)
+	}
+}
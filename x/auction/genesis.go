@@ -0,0 +1,189 @@
+package auction
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// ValidateGenesis checks the auction section and reports every problem found with the record
+// and field it was found at. The lots are checked against their assets by the app.
+func ValidateGenesis(data GenesisState) error {
+	var r genesisReport
+	if err := data.Params.Validate(); err != nil {
+		r.addf("params", "%s", err)
+	}
+
+	auctions := make(map[string]Auction, len(data.AuctionRecords))
+	for i, record := range data.AuctionRecords {
+		field := fmt.Sprintf("auction_records[%d]", i)
+		if record.Lot == "" {
+			r.addf(field+".lot", "missing lot")
+		} else if _, ok := auctions[record.Lot]; ok {
+			r.addf(field+".lot", "duplicate lot %s", record.Lot)
+		} else {
+			auctions[record.Lot] = record
+		}
+		if record.Owner.Empty() && !record.IsInitial() {
+			r.addf(field+".owner", "missing owner")
+		}
+		if !record.Type.IsValid() {
+			r.addf(field+".type", "unknown auction type %s", record.Type)
+		}
+		if record.Asset == "" {
+			r.addf(field+".asset", "missing asset")
+		}
+		r.checkCoins(field, []namedCoins{
+			{".reserve_price", record.ReservePrice}, {".bid_price", record.BidPrice}, {".bond", record.Bond},
+			{".start_price", record.StartPrice}, {".price_step", record.PriceStep}, {".proxy_max", record.ProxyMax},
+			{".buy_now_price", record.BuyNowPrice}, {".reserve_step", record.ReserveStep}, {".floor_price", record.FloorPrice},
+		})
+		r.checkSchedule(field, record)
+	}
+
+	seqs := make(map[string]uint64)
+	for i, bid := range data.Bids {
+		field := fmt.Sprintf("bids[%d]", i)
+		auction, ok := auctions[bid.Lot]
+		if !ok {
+			r.addf(field+".lot", "no auction of lot %s", bid.Lot)
+		} else if seqs[bid.Lot] >= auction.BidCount {
+			r.addf(field+".lot", "auction %s has a bid count of %d", bid.Lot, auction.BidCount)
+		}
+		seqs[bid.Lot]++
+		if bid.Bidder.Empty() {
+			r.addf(field+".bidder", "missing bidder")
+		}
+		r.checkCoins(field, []namedCoins{{".price", bid.Price}})
+	}
+	for lot, auction := range auctions {
+		if seqs[lot] < auction.BidCount {
+			r.addf("bids", "auction %s has a bid count of %d but %d bids", lot, auction.BidCount, seqs[lot])
+		}
+	}
+
+	for i, bid := range data.SealedBids {
+		field := fmt.Sprintf("sealed_bids[%d]", i)
+		if auction, ok := auctions[bid.Lot]; !ok || !auction.IsSealed() {
+			r.addf(field+".lot", "no sealed-bid auction of lot %s", bid.Lot)
+		}
+		if bid.Bidder.Empty() {
+			r.addf(field+".bidder", "missing bidder")
+		}
+		r.checkCoins(field, []namedCoins{{".deposit", bid.Deposit}, {".bid_price", bid.BidPrice}})
+		if bid.Revealed && !bid.Deposit.IsAllGTE(bid.BidPrice) {
+			r.addf(field+".bid_price", "%s is more than the deposit %s", bid.BidPrice, bid.Deposit)
+		}
+	}
+
+	for i, settled := range data.SettledAuctions {
+		if settled.ID >= data.SettledAuctionCount {
+			r.addf(fmt.Sprintf("settled_auctions[%d].id", i), "%d is not below the settled auction count %d",
+				settled.ID, data.SettledAuctionCount)
+		}
+	}
+	return r.err()
+}
+
+// genesisReport collects the problems of a genesis, each prefixed by the record and field it
+// was found at
+type genesisReport []string
+
+func (r *genesisReport) addf(field string, format string, args ...interface{}) {
+	*r = append(*r, field+": "+fmt.Sprintf(format, args...))
+}
+
+func (r genesisReport) err() error {
+	if len(r) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d problems in the auction genesis:\n\t%s", len(r), strings.Join(r, "\n\t"))
+}
+
+type namedCoins struct {
+	field string
+	coins sdk.Coins
+}
+
+// checkCoins reports the coins that are set but invalid
+func (r *genesisReport) checkCoins(field string, coins []namedCoins) {
+	for _, c := range coins {
+		if !c.coins.Empty() && !c.coins.IsValid() {
+			r.addf(field+c.field, "invalid coins %s", c.coins)
+		}
+	}
+}
+
+// checkSchedule checks the deadlines of an auction fit its type. Heights are those of the new
+// chain, which starts at height 1, so an auction must not have ended before it. Whether the end
+// times are after genesis is checked by the app, which knows the genesis time.
+func (r *genesisReport) checkSchedule(field string, record Auction) {
+	switch {
+	case record.IsTimed() && record.IsDutch():
+		r.addf(field+".end_time", "dutch auctions run on block heights")
+	case record.IsTimed() && record.IsSealed() && record.RevealEndTime.Before(record.EndTime):
+		r.addf(field+".reveal_end_time", "%s is before the end time %s", record.RevealEndTime, record.EndTime)
+	case record.IsTimed():
+	case !record.RevealEndTime.IsZero():
+		r.addf(field+".reveal_end_time", "set without an end time")
+	case record.IsSealed() && record.RevealDeadline < record.Deadline:
+		r.addf(field+".reveal_deadline", "height %d is before the deadline %d", record.RevealDeadline, record.Deadline)
+	case record.IsDutch() && record.StartHeight > record.Deadline:
+		r.addf(field+".start_height", "height %d is after the deadline %d", record.StartHeight, record.Deadline)
+	case record.EndHeight() < 0:
+		r.addf(field+".deadline", "height %d has passed at genesis", record.EndHeight())
+	}
+}
+
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) []abci.ValidatorUpdate {
+	keeper.SetParams(ctx, data.Params)
+	for _, record := range data.AuctionRecords {
+		keeper.SetAuction(ctx, record.Lot, record, true)
+	}
+	seqs := make(map[string]uint64)
+	for _, bid := range data.Bids {
+		keeper.SetBidAt(ctx, seqs[bid.Lot], bid)
+		seqs[bid.Lot]++
+	}
+	for _, bid := range data.SealedBids {
+		keeper.SetSealedBid(ctx, bid)
+	}
+	for _, settled := range data.SettledAuctions {
+		keeper.SetSettledAuction(ctx, settled)
+	}
+	keeper.SetSettledAuctionCount(ctx, data.SettledAuctionCount)
+	return []abci.ValidatorUpdate{}
+}
+
+func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	auctionRecords := []Auction{}
+	bids := []Bid{}
+	sealedBids := []SealedBid{}
+	iterator := k.GetAuctionIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var auction Auction
+		ModuleCdc.MustUnmarshalBinaryBare(iterator.Value(), &auction)
+		auctionRecords = append(auctionRecords, auction)
+		bids = append(bids, k.GetBids(ctx, auction.Lot)...)
+		sealedBids = append(sealedBids, k.GetSealedBids(ctx, auction.Lot)...)
+	}
+
+	// the archive iterates most recent first, genesis keeps it in ID order
+	settledAuctions := []SettledAuction{}
+	settledIterator := k.GetSettledAuctionsIterator(ctx)
+	defer settledIterator.Close()
+	for ; settledIterator.Valid(); settledIterator.Next() {
+		var settled SettledAuction
+		ModuleCdc.MustUnmarshalBinaryBare(settledIterator.Value(), &settled)
+		settledAuctions = append(settledAuctions, settled)
+	}
+	for i, j := 0, len(settledAuctions)-1; i < j; i, j = i+1, j-1 {
+		settledAuctions[i], settledAuctions[j] = settledAuctions[j], settledAuctions[i]
+	}
+
+	return NewGenesisState(k.GetParams(ctx), auctionRecords, bids, sealedBids,
+		settledAuctions, k.GetSettledAuctionCount(ctx))
+}
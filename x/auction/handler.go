@@ -0,0 +1,383 @@
+package auction
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/rune/baseapp/x/auction/internal/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// NewHandler returns a handler for "auction" type messages.
+func NewHandler(keeper Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		switch msg := msg.(type) {
+		case types.MsgAuction:
+			return handleMsgAuction(ctx, keeper, msg)
+		case types.MsgBid:
+			return handleMsgBid(ctx, keeper, msg)
+		case types.MsgCommitBid:
+			return handleMsgCommitBid(ctx, keeper, msg)
+		case types.MsgRevealBid:
+			return handleMsgRevealBid(ctx, keeper, msg)
+		case types.MsgCancelAuction:
+			return handleMsgCancelAuction(ctx, keeper, msg)
+		case types.MsgUpdateAllowlist:
+			return handleMsgUpdateAllowlist(ctx, keeper, msg)
+		default:
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, fmt.Sprintf("Unrecognized auction Msg type: %v", msg.Type()))
+		}
+	}
+}
+
+func handleMsgAuction(ctx sdk.Context, keeper Keeper, msg types.MsgAuction) (*sdk.Result, error) {
+	if keeper.HasAuction(ctx, msg.Lot) {
+		return nil, sdkerrors.Wrap(types.ErrAuctionExist, fmt.Sprintf("Auction %s has existed", msg.Lot))
+	}
+	if !keeper.Router().HasRoute(msg.Asset) {
+		return nil, sdkerrors.Wrap(types.ErrUnknownAsset, msg.Asset)
+	}
+	if !msg.ReservePrice.IsAllPositive() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInsufficientFee, "Reserve Price should be positive")
+	}
+	if !msg.EndTime.IsZero() && !msg.EndTime.After(ctx.BlockTime()) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "End Time has passed")
+	}
+	params := keeper.GetParams(ctx)
+	if msg.Initial {
+		if err := checkInitialTerms(msg, params); err != nil {
+			return nil, err
+		}
+	}
+	// the bond of a chain-run auction is posted by the account opening it
+	if err := keeper.EscrowCoins(ctx, msg.Owner, params.AuctionBond); err != nil {
+		return nil, sdkerrors.Wrap(err, "Seller bond")
+	}
+	auction := types.Auction{Lot: msg.Lot, Asset: msg.Asset, Owner: msg.Owner, Type: msg.AuctionType, ReservePrice: msg.ReservePrice,
+		Bond: params.AuctionBond}
+	auction.Escrowed = auction.IsEnglish() && params.EscrowBids
+	auction.BuyNowPrice = msg.BuyNowPrice
+	auction.Relists, auction.ReserveStep, auction.FloorPrice = msg.Relists, msg.ReserveStep, msg.FloorPrice
+	auction.Private, auction.Allowlist = len(msg.Allowlist) != 0, msg.Allowlist
+	auction.Items = msg.Items
+	auction.EndTime = msg.EndTime
+	if msg.Initial {
+		auction.Owner, auction.Opener = nil, msg.Owner
+	}
+	if auction.IsDutch() {
+		auction.StartPrice = msg.StartPrice
+		auction.PriceStep = msg.PriceStep
+	}
+	if err := keeper.StartAuction(ctx, auction); err != nil {
+		return nil, err
+	}
+	return &sdk.Result{}, nil
+}
+
+// checkInitialTerms keeps a chain-run auction to the terms the chain sets: no dutch curve, no
+// buy-now price and a reserve of at least MinInitialReserve, also after relisting
+func checkInitialTerms(msg types.MsgAuction, params types.Params) error {
+	switch {
+	case msg.AuctionType == types.AuctionTypeDutch:
+		return sdkerrors.Wrap(types.ErrInitialTerms, "Chain-run auctions cannot be dutch")
+	case !msg.BuyNowPrice.Empty():
+		return sdkerrors.Wrap(types.ErrInitialTerms, "Chain-run auctions have no buy-now price")
+	case !msg.ReservePrice.IsAllGTE(params.MinInitialReserve):
+		return sdkerrors.Wrap(types.ErrInitialTerms, fmt.Sprintf("Reserve Price %s is below %s", msg.ReservePrice, params.MinInitialReserve))
+	case msg.Relists > 0 && !msg.FloorPrice.IsAllGTE(params.MinInitialReserve):
+		return sdkerrors.Wrap(types.ErrInitialTerms, fmt.Sprintf("Floor Price %s is below %s", msg.FloorPrice, params.MinInitialReserve))
+	}
+	return nil
+}
+
+func handleMsgBid(ctx sdk.Context, keeper Keeper, msg types.MsgBid) (*sdk.Result, error) {
+	if !keeper.HasAuction(ctx, msg.Lot) {
+		return nil, sdkerrors.Wrap(types.ErrAuctionDoesNotExist, fmt.Sprintf("Auction %s is not existed", msg.Lot))
+	}
+	auction := keeper.GetAuction(ctx, msg.Lot)
+	if auction.IsSealed() {
+		return nil, sdkerrors.Wrap(types.ErrInvalidAuctionType, "sealed-bid auctions take commit-bid and reveal-bid")
+	}
+	// auctions over the per block settlement cap stay in the store for a few blocks past their deadline
+	if auction.IsOver(ctx.BlockHeight(), ctx.BlockTime()) {
+		return nil, sdkerrors.Wrap(types.ErrAuctionPhase, "auction is over")
+	}
+	// a bid of the owner would only keep the auction from ending
+	if msg.Bidder.Equals(auction.Owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "Owner cannot bid on its own auction")
+	}
+	if !auction.IsAllowed(msg.Bidder) {
+		return nil, sdkerrors.Wrap(types.ErrBidderNotAllowed, msg.Bidder.String())
+	}
+	if !msg.MaxPrice.Empty() && !auction.IsEnglish() {
+		return nil, sdkerrors.Wrap(types.ErrInvalidAuctionType, "only english auctions take proxy bids")
+	}
+	if auction.IsDutch() {
+		return handleDutchBid(ctx, keeper, msg, auction)
+	}
+	if auction.OffersBuyNow() && msg.BidPrice.IsAllGTE(auction.BuyNowPrice) {
+		return handleBuyNow(ctx, keeper, msg, auction)
+	}
+	if msg.BuyNow {
+		if !auction.OffersBuyNow() {
+			return nil, sdkerrors.Wrap(types.ErrBidPriceTooLow, "the auction no longer offers a buy-now price")
+		}
+		return nil, sdkerrors.Wrap(types.ErrBidPriceTooLow, fmt.Sprintf("%s does not reach the buy-now price", msg.BidPrice))
+	}
+	if !msg.BidPrice.IsAllGT(auction.ReservePrice) {
+		return nil, sdkerrors.Wrap(types.ErrBidPriceTooLow, msg.BidPrice.String())
+	}
+	if !msg.BidPrice.IsAllGT(auction.BidPrice) {
+		return nil, sdkerrors.Wrap(types.ErrBidPriceTooLow, msg.BidPrice.String())
+	}
+
+	// a proxy bid escrows its maximum, a plain bid only escrows when the auction does
+	maxPrice := msg.MaxPrice
+	var escrow sdk.Coins
+	switch {
+	case !maxPrice.Empty():
+		escrow = maxPrice
+	case auction.Escrowed:
+		escrow = msg.BidPrice
+	}
+	if maxPrice.Empty() {
+		maxPrice = msg.BidPrice
+	}
+	// the high bidder raising its own bid swaps its escrow, it only has to cover the new amount
+	rival := !msg.Bidder.Equals(auction.Bidder)
+	if !rival {
+		if err := keeper.ReleaseCoins(ctx, auction.Bidder, auction.HeldEscrow()); err != nil {
+			return nil, err
+		}
+	}
+	if err := keeper.EscrowCoins(ctx, msg.Bidder, escrow); err != nil {
+		return nil, err
+	}
+
+	// a standing proxy bid that is not outbid answers with the increment, capped at its maximum
+	increment := keeper.GetParams(ctx).MinBidIncrement
+	if rival && !auction.ProxyMax.Empty() && !maxPrice.IsAllGT(auction.ProxyMax) {
+		if err := keeper.ReleaseCoins(ctx, msg.Bidder, escrow); err != nil {
+			return nil, err
+		}
+		keeper.RecordBid(ctx, msg.Lot, maxPrice, msg.Bidder)
+		answer := lowerCoins(auction.ProxyMax, maxPrice.Add(increment...))
+		keeper.SetProxyBid(ctx, msg.Lot, answer, auction.Bidder, auction.ProxyMax)
+		return &sdk.Result{}, nil
+	}
+
+	// the new bid wins, a proxy bid only shows the lowest level beating the standing bid
+	price := msg.BidPrice
+	if rival && !msg.MaxPrice.Empty() && !auction.Bidder.Empty() {
+		standing := auction.BidPrice
+		if !auction.ProxyMax.Empty() {
+			standing = auction.ProxyMax
+		}
+		if level := lowerCoins(maxPrice, standing.Add(increment...)); level.IsAllGT(price) {
+			price = level
+		}
+	}
+	if rival {
+		if !auction.ProxyMax.Empty() {
+			// the outbid proxy went all the way up to its maximum
+			keeper.RecordBid(ctx, msg.Lot, auction.ProxyMax, auction.Bidder)
+		}
+		if err := keeper.ReleaseCoins(ctx, auction.Bidder, auction.HeldEscrow()); err != nil {
+			return nil, err
+		}
+	}
+	keeper.SetProxyBid(ctx, msg.Lot, price, msg.Bidder, msg.MaxPrice)
+	return &sdk.Result{}, nil
+}
+
+// lowerCoins returns the lower of two amounts
+func lowerCoins(a, b sdk.Coins) sdk.Coins {
+	if a.IsAllLTE(b) {
+		return a
+	}
+	return b
+}
+
+// handleDutchBid settles a dutch auction on the first bid covering the current price. The
+// winner pays the current price, not the bid, which only caps what the bidder accepts to pay.
+func handleDutchBid(ctx sdk.Context, keeper Keeper, msg types.MsgBid, auction types.Auction) (*sdk.Result, error) {
+	price := auction.CurrentPrice(ctx.BlockHeight())
+	if !msg.BidPrice.IsAllGTE(price) {
+		return nil, sdkerrors.Wrap(types.ErrBidPriceTooLow, fmt.Sprintf("%s, current price is %s", msg.BidPrice, price))
+	}
+	if err := keeper.PayProceeds(ctx, auction, msg.Bidder, price); err != nil {
+		return nil, err
+	}
+	transferLot(ctx, keeper, auction, msg.Bidder, price)
+	return &sdk.Result{}, nil
+}
+
+// handleBuyNow ends an english auction for a bid reaching its buy-now price. The buyer pays the
+// buy-now price to the seller and an escrowed high bid is refunded.
+func handleBuyNow(ctx sdk.Context, keeper Keeper, msg types.MsgBid, auction types.Auction) (*sdk.Result, error) {
+	if err := keeper.PayProceeds(ctx, auction, msg.Bidder, auction.BuyNowPrice); err != nil {
+		return nil, err
+	}
+	if err := keeper.ReleaseCoins(ctx, auction.Bidder, auction.HeldEscrow()); err != nil {
+		return nil, err
+	}
+	transferLot(ctx, keeper, auction, msg.Bidder, auction.BuyNowPrice)
+	return &sdk.Result{}, nil
+}
+
+func handleMsgCommitBid(ctx sdk.Context, keeper Keeper, msg types.MsgCommitBid) (*sdk.Result, error) {
+	if !keeper.HasAuction(ctx, msg.Lot) {
+		return nil, sdkerrors.Wrap(types.ErrAuctionDoesNotExist, fmt.Sprintf("Auction %s is not existed", msg.Lot))
+	}
+	auction := keeper.GetAuction(ctx, msg.Lot)
+	if !auction.IsSealed() {
+		return nil, sdkerrors.Wrap(types.ErrInvalidAuctionType, "only sealed-bid auctions take commitments")
+	}
+	if auction.IsCommitOver(ctx.BlockHeight(), ctx.BlockTime()) {
+		return nil, sdkerrors.Wrap(types.ErrAuctionPhase, "commit phase is over")
+	}
+	if msg.Bidder.Equals(auction.Owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "Owner cannot bid on its own auction")
+	}
+	if !auction.IsAllowed(msg.Bidder) {
+		return nil, sdkerrors.Wrap(types.ErrBidderNotAllowed, msg.Bidder.String())
+	}
+	if keeper.HasSealedBid(ctx, msg.Lot, msg.Bidder) {
+		return nil, sdkerrors.Wrap(types.ErrSealedBidExist, msg.Bidder.String())
+	}
+	if err := keeper.EscrowCoins(ctx, msg.Bidder, msg.Deposit); err != nil {
+		return nil, err
+	}
+	keeper.SetSealedBid(ctx, types.SealedBid{Lot: msg.Lot, Bidder: msg.Bidder, Hash: msg.Hash, Deposit: msg.Deposit})
+	return &sdk.Result{}, nil
+}
+
+func handleMsgRevealBid(ctx sdk.Context, keeper Keeper, msg types.MsgRevealBid) (*sdk.Result, error) {
+	if !keeper.HasAuction(ctx, msg.Lot) {
+		return nil, sdkerrors.Wrap(types.ErrAuctionDoesNotExist, fmt.Sprintf("Auction %s is not existed", msg.Lot))
+	}
+	auction := keeper.GetAuction(ctx, msg.Lot)
+	if !auction.IsSealed() {
+		return nil, sdkerrors.Wrap(types.ErrInvalidAuctionType, "only sealed-bid auctions take reveals")
+	}
+	if !auction.IsCommitOver(ctx.BlockHeight(), ctx.BlockTime()) || auction.IsOver(ctx.BlockHeight(), ctx.BlockTime()) {
+		return nil, sdkerrors.Wrap(types.ErrAuctionPhase, "auction is not in its reveal phase")
+	}
+	if !keeper.HasSealedBid(ctx, msg.Lot, msg.Bidder) {
+		return nil, sdkerrors.Wrap(types.ErrSealedBidNotExist, msg.Bidder.String())
+	}
+	bid := keeper.GetSealedBid(ctx, msg.Lot, msg.Bidder)
+	if bid.Revealed {
+		return nil, sdkerrors.Wrap(types.ErrSealedBidExist, "bid has been revealed")
+	}
+	if !bytes.Equal(bid.Hash, types.SealedBidHash(msg.Lot, msg.Bidder, msg.BidPrice, msg.Salt)) {
+		return nil, types.ErrInvalidReveal
+	}
+	if !bid.Deposit.IsAllGTE(msg.BidPrice) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInsufficientFunds, "Deposit does not cover the bid")
+	}
+	bid.Revealed = true
+	bid.BidPrice = msg.BidPrice
+	keeper.SetSealedBid(ctx, bid)
+	return &sdk.Result{}, nil
+}
+
+// handleMsgCancelAuction withdraws an auction. Before any bid the seller bond is refunded, afterwards
+// it is slashed: part of it compensates the displaced high bidder, the rest goes to the fee collector.
+func handleMsgCancelAuction(ctx sdk.Context, keeper Keeper, msg types.MsgCancelAuction) (*sdk.Result, error) {
+	if !keeper.HasAuction(ctx, msg.Lot) {
+		return nil, sdkerrors.Wrap(types.ErrAuctionDoesNotExist, fmt.Sprintf("Auction %s is not existed", msg.Lot))
+	}
+	auction := keeper.GetAuction(ctx, msg.Lot)
+	if !msg.Owner.Equals(auction.Owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "Incorrect Owner")
+	}
+	// an auction past its deadline is only waiting to be settled, its result stands
+	if auction.IsOver(ctx.BlockHeight(), ctx.BlockTime()) {
+		return nil, sdkerrors.Wrap(types.ErrAuctionPhase, "auction is over")
+	}
+
+	var hasBids bool
+	var highBidder sdk.AccAddress
+	switch {
+	case auction.IsSealed():
+		var highPrice sdk.Coins
+		for _, bid := range keeper.GetSealedBids(ctx, msg.Lot) {
+			hasBids = true
+			if bid.Revealed && (highBidder.Empty() || bid.BidPrice.IsAllGT(highPrice)) {
+				highBidder, highPrice = bid.Bidder, bid.BidPrice
+			}
+			// nobody but the seller is to blame, every deposit goes back
+			if err := keeper.ReleaseCoins(ctx, bid.Bidder, bid.Deposit); err != nil {
+				return nil, err
+			}
+			keeper.DeleteSealedBid(ctx, msg.Lot, bid.Bidder)
+		}
+	case !auction.Bidder.Empty() && !auction.Bidder.Equals(auction.Owner):
+		hasBids = true
+		highBidder = auction.Bidder
+	}
+	if err := keeper.ReleaseCoins(ctx, auction.Bidder, auction.HeldEscrow()); err != nil {
+		return nil, err
+	}
+
+	if hasBids {
+		var compensation sdk.Coins
+		if !highBidder.Empty() {
+			compensation, _ = sdk.NewDecCoinsFromCoins(auction.Bond...).MulDecTruncate(keeper.GetParams(ctx).CancelCompensationRate).TruncateDecimal()
+			if err := keeper.ReleaseCoins(ctx, highBidder, compensation); err != nil {
+				return nil, err
+			}
+		}
+		if err := keeper.CollectFees(ctx, auction.Bond.Sub(compensation)); err != nil {
+			return nil, err
+		}
+		auction.Bond = nil
+	}
+	closeAuction(ctx, keeper, auction, types.OutcomeCancelled, nil, nil)
+	return &sdk.Result{}, nil
+}
+
+// handleMsgUpdateAllowlist changes who may bid on a private auction. Removing a bidder does not
+// withdraw the bids it has already placed.
+func handleMsgUpdateAllowlist(ctx sdk.Context, keeper Keeper, msg types.MsgUpdateAllowlist) (*sdk.Result, error) {
+	if !keeper.HasAuction(ctx, msg.Lot) {
+		return nil, sdkerrors.Wrap(types.ErrAuctionDoesNotExist, fmt.Sprintf("Auction %s is not existed", msg.Lot))
+	}
+	auction := keeper.GetAuction(ctx, msg.Lot)
+	if !msg.Owner.Equals(auction.Owner) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "Incorrect Owner")
+	}
+	if !auction.Private {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "auction is not private")
+	}
+	if auction.IsOver(ctx.BlockHeight(), ctx.BlockTime()) {
+		return nil, sdkerrors.Wrap(types.ErrAuctionPhase, "auction is over")
+	}
+
+	allowlist := make([]sdk.AccAddress, 0, len(auction.Allowlist)+len(msg.Add))
+	for _, addr := range auction.Allowlist {
+		if !containsAddress(msg.Remove, addr) {
+			allowlist = append(allowlist, addr)
+		}
+	}
+	for _, addr := range msg.Add {
+		if !containsAddress(allowlist, addr) {
+			allowlist = append(allowlist, addr)
+		}
+	}
+	auction.Allowlist = allowlist
+	keeper.UpdateAuction(ctx, auction)
+	return &sdk.Result{}, nil
+}
+
+func containsAddress(addrs []sdk.AccAddress, addr sdk.AccAddress) bool {
+	for _, a := range addrs {
+		if a.Equals(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,398 @@
+package auction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/rune/baseapp/x/auction/internal/types"
+)
+
+func TestInitialAuctionTerms(t *testing.T) {
+	input := createTestInput(t)
+	opener := input.newFundedAddr(t, 1000)
+	initial := func(lot string, reserve sdk.Coins) MsgAuction {
+		msg := NewAuction(testAssetRoute, lot, opener, reserve, AuctionTypeEnglish)
+		msg.Initial = true
+		return msg
+	}
+
+	cheap := initial("cheap", coins(1))
+	otherDenom := initial("other", sdk.NewCoins(sdk.NewInt64Coin("stake", 1000)))
+	buyNow := initial("buynow", coins(100))
+	buyNow.BuyNowPrice = coins(101)
+	dutch := NewDutchAuction(testAssetRoute, "dutch", opener, coins(100), coins(200), coins(1))
+	dutch.Initial = true
+	relisted := initial("relisted", coins(100))
+	relisted.Relists, relisted.ReserveStep, relisted.FloorPrice = 3, coins(50), coins(1)
+
+	for _, msg := range []MsgAuction{cheap, otherDenom, buyNow, dutch, relisted} {
+		require.NoError(t, msg.ValidateBasic(), msg.Lot)
+		_, err := input.handler(input.ctx, msg)
+		require.True(t, types.ErrInitialTerms.Is(err), "%s: %v", msg.Lot, err)
+		require.False(t, input.k.HasAuction(input.ctx, msg.Lot))
+	}
+	require.Equal(t, int64(1000), input.balance(opener))
+
+	_, err := input.handler(input.ctx, initial("fair", coins(100)))
+	require.NoError(t, err)
+	a := input.k.GetAuction(input.ctx, "fair")
+	require.True(t, a.IsInitial())
+	require.Equal(t, opener, a.Opener)
+}
+
+func TestLotClaimedByAnotherAsset(t *testing.T) {
+	input := createTestInput(t)
+	seller := input.newFundedAddr(t, 1000)
+	input.asset.owners["alice.id"] = input.newFundedAddr(t, 0)
+
+	msg := NewAuction(CoinsAssetRoute, "alice.id", seller, coins(10), AuctionTypeEnglish)
+	msg.Items = []string{"100nametoken"}
+	// a failed message is not committed, the bond it escrowed goes with it
+	cacheCtx, _ := input.ctx.CacheContext()
+	_, err := input.handler(cacheCtx, msg)
+	require.True(t, types.ErrLotClaimed.Is(err), "%v", err)
+	require.False(t, input.k.HasAuction(input.ctx, "alice.id"))
+
+	msg.Lot = "coins.lot"
+	_, err = input.handler(input.ctx, msg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000-100)-DefaultParams().AuctionBond.AmountOf("nametoken").Int64(), input.balance(seller))
+}
+
+func TestOwnerCannotBid(t *testing.T) {
+	input := createTestInput(t)
+	seller := input.newFundedAddr(t, 1000)
+	_, err := input.handler(input.ctx, NewAuction(testAssetRoute, "lot", seller, coins(10), AuctionTypeEnglish))
+	require.NoError(t, err)
+	deadline := input.k.GetAuction(input.ctx, "lot").Deadline
+
+	ctx := input.ctx.WithBlockHeight(50)
+	for _, msg := range []MsgBid{NewBid("lot", seller, coins(20)), NewProxyBid("lot", seller, coins(20), coins(50))} {
+		_, err = input.handler(ctx, msg)
+		require.True(t, sdkerrors.ErrUnauthorized.Is(err), "%v", err)
+	}
+	a := input.k.GetAuction(ctx, "lot")
+	require.Equal(t, deadline, a.Deadline)
+	require.Empty(t, a.Bidder)
+	require.Empty(t, input.k.GetBids(ctx, "lot"))
+}
+
+func TestDutchAuctionPrice(t *testing.T) {
+	input := createTestInput(t)
+	seller := input.newFundedAddr(t, 1000)
+	_, err := input.handler(input.ctx, NewDutchAuction(testAssetRoute, "lot", seller, coins(10), coins(100), coins(5)))
+	require.NoError(t, err)
+	a := input.k.GetAuction(input.ctx, "lot")
+	require.Equal(t, coins(50), a.CurrentPrice(11))
+	require.Equal(t, coins(10), a.CurrentPrice(100))
+
+	bidder := input.newFundedAddr(t, 1000)
+	ctx := input.ctx.WithBlockHeight(11)
+	_, err = input.handler(ctx, NewBid("lot", bidder, coins(45)))
+	require.True(t, types.ErrBidPriceTooLow.Is(err), "%v", err)
+
+	// the first bid covering the asking price wins at the asking price
+	_, err = input.handler(ctx, NewBid("lot", bidder, coins(60)))
+	require.NoError(t, err)
+	require.False(t, input.k.HasAuction(ctx, "lot"))
+	require.Equal(t, bidder, input.asset.owners["lot"])
+	require.Equal(t, int64(1000-50), input.balance(bidder))
+	require.Equal(t, int64(1000+50), input.balance(seller))
+	require.Equal(t, coins(50), input.lastSettled(t).Price)
+}
+
+func TestCancelAuction(t *testing.T) {
+	input := createTestInput(t)
+	seller := input.newFundedAddr(t, 1000)
+	bond := DefaultParams().AuctionBond.AmountOf("nametoken").Int64()
+	for _, lot := range []string{"quiet", "bid"} {
+		_, err := input.handler(input.ctx, NewAuction(testAssetRoute, lot, seller, coins(10), AuctionTypeEnglish))
+		require.NoError(t, err)
+	}
+	require.Equal(t, int64(1000-2*bond), input.balance(seller))
+
+	// only the seller cancels, before any bid the bond is refunded
+	_, err := input.handler(input.ctx, NewCancelAuction("quiet", input.newFundedAddr(t, 0)))
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err), "%v", err)
+	_, err = input.handler(input.ctx, NewCancelAuction("quiet", seller))
+	require.NoError(t, err)
+	require.Equal(t, int64(1000-bond), input.balance(seller))
+	require.NotContains(t, input.asset.locked, "quiet")
+
+	// after a bid the bond is slashed, part of it compensates the displaced high bidder
+	bidder := input.newFundedAddr(t, 100)
+	_, err = input.handler(input.ctx, NewBid("bid", bidder, coins(20)))
+	require.NoError(t, err)
+	_, err = input.handler(input.ctx, NewCancelAuction("bid", seller))
+	require.NoError(t, err)
+	compensation := DefaultParams().CancelCompensationRate.MulInt64(bond).TruncateInt64()
+	require.Equal(t, int64(1000-bond), input.balance(seller))
+	require.Equal(t, int64(100)+compensation, input.balance(bidder))
+	require.Equal(t, bond-compensation, input.moduleBalance(auth.FeeCollectorName))
+	require.Zero(t, input.moduleBalance(ModuleName))
+	require.Equal(t, OutcomeCancelled, input.lastSettled(t).Outcome)
+	require.Nil(t, input.asset.owners["bid"])
+}
+
+func TestCancelSealedAuctionRefundsDeposits(t *testing.T) {
+	input := createTestInput(t)
+	seller := input.newFundedAddr(t, 1000)
+	_, err := input.handler(input.ctx, NewAuction(testAssetRoute, "lot", seller, coins(10), AuctionTypeSealed))
+	require.NoError(t, err)
+	a := input.k.GetAuction(input.ctx, "lot")
+	high, silent := input.newFundedAddr(t, 100), input.newFundedAddr(t, 100)
+	reveal := input.sealedBid(t, "lot", high, 40, 50)
+	input.sealedBid(t, "lot", silent, 60, 60)
+	ctx := input.ctx.WithBlockHeight(a.Deadline + 1)
+	_, err = input.handler(ctx, reveal)
+	require.NoError(t, err)
+
+	// the seller is to blame, every deposit goes back and the highest revealed bid is compensated
+	_, err = input.handler(ctx, NewCancelAuction("lot", seller))
+	require.NoError(t, err)
+	bond := DefaultParams().AuctionBond.AmountOf("nametoken").Int64()
+	compensation := DefaultParams().CancelCompensationRate.MulInt64(bond).TruncateInt64()
+	require.Equal(t, int64(100)+compensation, input.balance(high))
+	require.Equal(t, int64(100), input.balance(silent))
+	require.Empty(t, input.k.GetSealedBids(ctx, "lot"))
+	require.Zero(t, input.moduleBalance(ModuleName))
+}
+
+func TestPrivateAuctionAllowlist(t *testing.T) {
+	input := createTestInput(t)
+	seller := input.newFundedAddr(t, 1000)
+	listed, other := input.newFundedAddr(t, 1000), input.newFundedAddr(t, 1000)
+	msg := NewAuction(testAssetRoute, "lot", seller, coins(10), AuctionTypeEnglish)
+	msg.Allowlist = []sdk.AccAddress{listed}
+	_, err := input.handler(input.ctx, msg)
+	require.NoError(t, err)
+
+	_, err = input.handler(input.ctx, NewBid("lot", other, coins(20)))
+	require.True(t, types.ErrBidderNotAllowed.Is(err), "%v", err)
+	_, err = input.handler(input.ctx, NewBid("lot", listed, coins(20)))
+	require.NoError(t, err)
+
+	// only the seller changes the list, a removed bidder keeps the bids it placed
+	update := NewUpdateAllowlist("lot", seller, []sdk.AccAddress{other}, []sdk.AccAddress{listed})
+	_, err = input.handler(input.ctx, NewUpdateAllowlist("lot", other, []sdk.AccAddress{other}, nil))
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err), "%v", err)
+	_, err = input.handler(input.ctx, update)
+	require.NoError(t, err)
+	a := input.k.GetAuction(input.ctx, "lot")
+	require.Equal(t, []sdk.AccAddress{other}, a.Allowlist)
+	require.Equal(t, listed, a.Bidder)
+
+	_, err = input.handler(input.ctx, NewBid("lot", listed, coins(40)))
+	require.True(t, types.ErrBidderNotAllowed.Is(err), "%v", err)
+	_, err = input.handler(input.ctx, NewBid("lot", other, coins(30)))
+	require.NoError(t, err)
+
+	// a public auction has no list to change
+	_, err = input.handler(input.ctx, NewAuction(testAssetRoute, "public", seller, coins(10), AuctionTypeEnglish))
+	require.NoError(t, err)
+	_, err = input.handler(input.ctx, NewUpdateAllowlist("public", seller, []sdk.AccAddress{other}, nil))
+	require.True(t, sdkerrors.ErrInvalidRequest.Is(err), "%v", err)
+}
+
+func TestBundleAuction(t *testing.T) {
+	input := createTestInput(t)
+	seller := input.newFundedAddr(t, 1000)
+	msg := NewAuction(testAssetRoute, "bundle", seller, coins(10), AuctionTypeEnglish)
+	msg.Items = []string{"a", "b"}
+	_, err := input.handler(input.ctx, msg)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"a": "bundle", "b": "bundle"}, input.asset.locked)
+
+	// an item is only in one running auction
+	overlap := NewAuction(testAssetRoute, "other", seller, coins(10), AuctionTypeEnglish)
+	overlap.Items = []string{"b", "c"}
+	cacheCtx, _ := input.ctx.CacheContext()
+	_, err = input.handler(cacheCtx, overlap)
+	require.True(t, types.ErrAuctionExist.Is(err), "%v", err)
+
+	// the winner gets every item, the lot ID is no item
+	bidder := input.newFundedAddr(t, 100)
+	_, err = input.handler(input.ctx, NewBid("bundle", bidder, coins(20)))
+	require.NoError(t, err)
+	input.beginBlock(input.k.GetAuction(input.ctx, "bundle").Deadline+1, time.Time{})
+	require.Empty(t, input.asset.locked)
+	require.Equal(t, map[string]sdk.AccAddress{"a": bidder, "b": bidder}, input.asset.owners)
+}
+
+func TestTimedAuctionExtension(t *testing.T) {
+	input := createTestInput(t)
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	ctx := input.ctx.WithBlockTime(start)
+	seller := input.newFundedAddr(t, 1000)
+	msg := NewAuction(testAssetRoute, "lot", seller, coins(10), AuctionTypeEnglish)
+	msg.EndTime = end
+	_, err := input.handler(ctx, msg)
+	require.NoError(t, err)
+
+	// an early bid leaves the end time, a late one gives the others AuctionDuration to answer
+	bidder, late := input.newFundedAddr(t, 100), input.newFundedAddr(t, 100)
+	_, err = input.handler(ctx.WithBlockTime(start.Add(time.Minute)), NewBid("lot", bidder, coins(20)))
+	require.NoError(t, err)
+	require.Equal(t, end, input.k.GetAuction(ctx, "lot").EndTime)
+	lateTime := end.Add(-time.Minute)
+	_, err = input.handler(ctx.WithBlockTime(lateTime), NewBid("lot", late, coins(30)))
+	require.NoError(t, err)
+	extended := lateTime.Add(DefaultParams().AuctionDuration)
+	require.Equal(t, extended, input.k.GetAuction(ctx, "lot").EndTime)
+
+	// the height does not end a timed auction, and it outlives its original end time
+	input.beginBlock(1000, end.Add(time.Second))
+	require.True(t, input.k.HasAuction(input.ctx, "lot"))
+	input.beginBlock(1001, extended.Add(time.Second))
+	require.False(t, input.k.HasAuction(input.ctx, "lot"))
+	require.Equal(t, late, input.asset.owners["lot"])
+}
+
+// proxyAuction starts an english auction with a standing proxy bid of 20 up to 50
+func proxyAuction(t *testing.T) (testInput, sdk.AccAddress) {
+	input := createTestInput(t)
+	seller := input.newFundedAddr(t, 1000)
+	_, err := input.handler(input.ctx, NewAuction(testAssetRoute, "lot", seller, coins(10), AuctionTypeEnglish))
+	require.NoError(t, err)
+	proxy := input.newFundedAddr(t, 100)
+	_, err = input.handler(input.ctx, NewProxyBid("lot", proxy, coins(20), coins(50)))
+	require.NoError(t, err)
+	a := input.k.GetAuction(input.ctx, "lot")
+	require.Equal(t, coins(20), a.BidPrice)
+	require.Equal(t, coins(50), a.ProxyMax)
+	require.Equal(t, int64(100-50), input.balance(proxy))
+	return input, proxy
+}
+
+func TestProxyBidAnswersRivals(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		bid    MsgBid
+		answer int64
+	}{
+		{"plain bid below the max", NewBid("lot", nil, coins(30)), 31},
+		{"plain bid at the max", NewBid("lot", nil, coins(50)), 50},
+		{"proxy bid below the max", NewProxyBid("lot", nil, coins(25), coins(40)), 41},
+		{"proxy bid at the max", NewProxyBid("lot", nil, coins(25), coins(50)), 50},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			input, proxy := proxyAuction(t)
+			rival := input.newFundedAddr(t, 100)
+			tc.bid.Bidder = rival
+			_, err := input.handler(input.ctx, tc.bid)
+			require.NoError(t, err)
+
+			// the standing proxy stays ahead by the increment, the rival keeps nothing in escrow
+			a := input.k.GetAuction(input.ctx, "lot")
+			require.Equal(t, proxy, a.Bidder)
+			require.Equal(t, coins(tc.answer), a.BidPrice)
+			require.Equal(t, coins(50), a.ProxyMax)
+			require.Equal(t, int64(100), input.balance(rival))
+			require.Equal(t, int64(100-50), input.balance(proxy))
+			bids := input.k.GetBids(input.ctx, "lot")
+			require.Len(t, bids, 3)
+			require.Equal(t, rival, bids[1].Bidder)
+		})
+	}
+}
+
+func TestProxyBidOutbid(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		bid   MsgBid
+		price int64
+		held  int64
+	}{
+		{"plain bid above the max", NewBid("lot", nil, coins(60)), 60, 0},
+		{"proxy bid above the max", NewProxyBid("lot", nil, coins(25), coins(80)), 51, 80},
+		{"proxy bid just above the max", NewProxyBid("lot", nil, coins(25), coins(51)), 51, 51},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			input, proxy := proxyAuction(t)
+			rival := input.newFundedAddr(t, 100)
+			tc.bid.Bidder = rival
+			_, err := input.handler(input.ctx, tc.bid)
+			require.NoError(t, err)
+
+			// the outbid proxy went up to its max and gets its escrow back
+			a := input.k.GetAuction(input.ctx, "lot")
+			require.Equal(t, rival, a.Bidder)
+			require.Equal(t, coins(tc.price), a.BidPrice)
+			require.Equal(t, int64(100), input.balance(proxy))
+			require.Equal(t, 100-tc.held, input.balance(rival))
+			require.Equal(t, tc.held, input.moduleBalance(ModuleName)-DefaultParams().AuctionBond.AmountOf("nametoken").Int64())
+			bids := input.k.GetBids(input.ctx, "lot")
+			require.Len(t, bids, 3)
+			require.Equal(t, proxy, bids[1].Bidder)
+			require.Equal(t, coins(50), bids[1].Price)
+		})
+	}
+}
+
+func TestProxyBidRaisesOwnMax(t *testing.T) {
+	input, proxy := proxyAuction(t)
+	_, err := input.handler(input.ctx, NewProxyBid("lot", proxy, coins(21), coins(90)))
+	require.NoError(t, err)
+
+	// the new max replaces the old one in escrow, there is no rival to answer
+	a := input.k.GetAuction(input.ctx, "lot")
+	require.Equal(t, proxy, a.Bidder)
+	require.Equal(t, coins(21), a.BidPrice)
+	require.Equal(t, coins(90), a.ProxyMax)
+	require.Equal(t, int64(100-90), input.balance(proxy))
+
+	rival := input.newFundedAddr(t, 100)
+	_, err = input.handler(input.ctx, NewBid("lot", rival, coins(60)))
+	require.NoError(t, err)
+	require.Equal(t, coins(61), input.k.GetAuction(input.ctx, "lot").BidPrice)
+
+	// the winner pays its visible price and gets back the rest of its max
+	input.beginBlock(input.k.GetAuction(input.ctx, "lot").Deadline+1, time.Time{})
+	require.Equal(t, proxy, input.asset.owners["lot"])
+	require.Equal(t, int64(100-61), input.balance(proxy))
+	require.Equal(t, int64(100), input.balance(rival))
+	require.Zero(t, input.moduleBalance(ModuleName))
+}
+
+func TestBuyNowEndsAtTheHighBid(t *testing.T) {
+	input := createTestInput(t)
+	seller := input.newFundedAddr(t, 1000)
+	msg := NewAuction(testAssetRoute, "lot", seller, coins(10), AuctionTypeEnglish)
+	msg.BuyNowPrice = coins(50)
+	_, err := input.handler(input.ctx, msg)
+	require.NoError(t, err)
+
+	// the proxy answer brings the high bid to the buy-now price
+	proxy, rival := input.newFundedAddr(t, 100), input.newFundedAddr(t, 100)
+	_, err = input.handler(input.ctx, NewProxyBid("lot", proxy, coins(20), coins(60)))
+	require.NoError(t, err)
+	require.True(t, input.k.GetAuction(input.ctx, "lot").OffersBuyNow())
+	_, err = input.handler(input.ctx, NewBid("lot", rival, coins(49)))
+	require.NoError(t, err)
+	a := input.k.GetAuction(input.ctx, "lot")
+	require.Equal(t, proxy, a.Bidder)
+	require.Equal(t, coins(50), a.BidPrice)
+	require.False(t, a.OffersBuyNow())
+
+	// a bid at the buy-now price no longer wins below the high bid
+	buyer := input.newFundedAddr(t, 100)
+	_, err = input.handler(input.ctx, NewBuyNow("lot", buyer, coins(50)))
+	require.True(t, types.ErrBidPriceTooLow.Is(err), "%v", err)
+	_, err = input.handler(input.ctx, NewBid("lot", buyer, coins(50)))
+	require.True(t, types.ErrBidPriceTooLow.Is(err), "%v", err)
+	require.True(t, input.k.HasAuction(input.ctx, "lot"))
+	require.Equal(t, int64(100), input.balance(buyer))
+
+	_, err = input.handler(input.ctx, NewBid("lot", buyer, coins(61)))
+	require.NoError(t, err)
+	a = input.k.GetAuction(input.ctx, "lot")
+	require.Equal(t, buyer, a.Bidder)
+	require.Equal(t, coins(61), a.BidPrice)
+	require.Equal(t, int64(100), input.balance(proxy))
+}
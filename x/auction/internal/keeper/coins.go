@@ -0,0 +1,77 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/rune/baseapp/x/auction/internal/types"
+)
+
+// CoinsAsset sells coins, every item of a lot is a coin like 100stake. The coins are escrowed
+// in the auction module account while the auction runs. The seller can be a module account,
+// so modules can auction the coins they hold.
+type CoinsAsset struct {
+	supplyKeeper types.SupplyKeeper
+}
+
+// NewCoinsAsset returns the coins asset for the auction router
+func NewCoinsAsset(supplyKeeper types.SupplyKeeper) CoinsAsset {
+	return CoinsAsset{supplyKeeper: supplyKeeper}
+}
+
+// Lock escrows the coins of the lot
+func (a CoinsAsset) Lock(ctx sdk.Context, _ string, items []string, owner sdk.AccAddress) error {
+	if owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "coins are only sold by their owner")
+	}
+	coins, err := parseCoinItems(items)
+	if err != nil {
+		return err
+	}
+	return a.supplyKeeper.SendCoinsFromAccountToModule(ctx, owner, types.ModuleName, coins)
+}
+
+// Transfer pays the escrowed coins to the winner
+func (a CoinsAsset) Transfer(ctx sdk.Context, items []string, _, winner sdk.AccAddress, _ sdk.Coins) error {
+	coins, err := parseCoinItems(items)
+	if err != nil {
+		return err
+	}
+	return a.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, winner, coins)
+}
+
+// Unlock gives the escrowed coins back to the seller
+func (a CoinsAsset) Unlock(ctx sdk.Context, items []string, owner sdk.AccAddress) error {
+	coins, err := parseCoinItems(items)
+	if err != nil {
+		return err
+	}
+	return a.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, owner, coins)
+}
+
+// Locked returns the coins of the lot, they are held in the module account
+func (a CoinsAsset) Locked(_ sdk.Context, _ string, items []string, owner sdk.AccAddress) (sdk.Coins, error) {
+	if owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "coins are only sold by their owner")
+	}
+	return parseCoinItems(items)
+}
+
+// Claims is always false, coins are not kept under an ID
+func (a CoinsAsset) Claims(_ sdk.Context, _ string) bool {
+	return false
+}
+
+func parseCoinItems(items []string) (sdk.Coins, error) {
+	coins := sdk.NewCoins()
+	for _, item := range items {
+		coin, err := sdk.ParseCoin(item)
+		if err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
+		}
+		if !coin.IsPositive() {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, item)
+		}
+		coins = coins.Add(coin)
+	}
+	return coins, nil
+}
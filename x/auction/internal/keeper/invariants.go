@@ -0,0 +1,76 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rune/baseapp/x/auction/internal/types"
+)
+
+// RegisterInvariants registers all auction invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "lots", LotsInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "escrow", EscrowInvariant(k))
+}
+
+// AllInvariants runs all invariants of the auction module
+func AllInvariants(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		res, stop := LotsInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+		return EscrowInvariant(k)(ctx)
+	}
+}
+
+// LotsInvariant checks the lot of every open auction is locked by its asset and held by the
+// seller, e.g. the names of a name auction exist and are owned by the auction owner
+func LotsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		for _, auction := range k.GetAuctions(ctx) {
+			if !k.router.HasRoute(auction.Asset) {
+				count++
+				msg += fmt.Sprintf("\tauction %s sells the unknown asset %s\n", auction.Lot, auction.Asset)
+				continue
+			}
+			if _, err := k.router.GetRoute(auction.Asset).Locked(ctx, auction.Lot, auction.LotItems(), auction.Owner); err != nil {
+				count++
+				msg += fmt.Sprintf("\tauction %s: %s\n", auction.Lot, err)
+			}
+		}
+
+		broken := count != 0
+		return sdk.FormatInvariant(types.ModuleName, "lots",
+			fmt.Sprintf("%d auctions do not hold their lot\n%s", count, msg)), broken
+	}
+}
+
+// EscrowInvariant checks the auction module account holds exactly the seller bonds, escrowed
+// bids, sealed-bid deposits and escrowed lots of the open auctions
+func EscrowInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		expected := sdk.NewCoins()
+		for _, auction := range k.GetAuctions(ctx) {
+			expected = expected.Add(auction.Bond...).Add(auction.HeldEscrow()...)
+			for _, bid := range k.GetSealedBids(ctx, auction.Lot) {
+				expected = expected.Add(bid.Deposit...)
+			}
+			if k.router.HasRoute(auction.Asset) {
+				// a lot its asset no longer holds is reported by the lots invariant
+				if lot, err := k.router.GetRoute(auction.Asset).Locked(ctx, auction.Lot, auction.LotItems(), auction.Owner); err == nil {
+					expected = expected.Add(lot...)
+				}
+			}
+		}
+
+		balance := k.bankKeeper.GetCoins(ctx, k.supplyKeeper.GetModuleAddress(types.ModuleName))
+		diff, negative := balance.SafeSub(expected)
+		broken := negative || !diff.IsZero()
+		return sdk.FormatInvariant(types.ModuleName, "escrow",
+			fmt.Sprintf("\tescrow balance: %s\n\topen auctions hold: %s\n", balance, expected)), broken
+	}
+}
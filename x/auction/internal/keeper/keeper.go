@@ -0,0 +1,381 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/rune/baseapp/x/auction/internal/types"
+	"github.com/rune/baseapp/x/auction/util"
+)
+
+// Keeper of the auction store
+type Keeper struct {
+	storeKey         sdk.StoreKey
+	cdc              *codec.Codec
+	bankKeeper       types.BankKeeper
+	supplyKeeper     types.SupplyKeeper
+	feeCollectorName string
+	router           types.AssetRouter
+	paramspace       types.ParamSubspace
+}
+
+// NewKeeper creates new instances of the auction Keeper. The asset router is sealed, every
+// asset has to be added before.
+func NewKeeper(bankKeeper types.BankKeeper, supplyKeeper types.SupplyKeeper, storeKey sdk.StoreKey, cdc *codec.Codec,
+	feeCollectorName string, router types.AssetRouter, paramspace types.ParamSubspace) Keeper {
+	router.Seal()
+	return Keeper{
+		bankKeeper:       bankKeeper,
+		supplyKeeper:     supplyKeeper,
+		storeKey:         storeKey,
+		cdc:              cdc,
+		feeCollectorName: feeCollectorName,
+		router:           router,
+		paramspace:       paramspace.WithKeyTable(types.ParamKeyTable()),
+	}
+}
+
+// Router returns the assets the keeper can auction
+func (k Keeper) Router() types.AssetRouter {
+	return k.router
+}
+
+// StartAuction locks the lot with its asset and opens the auction at the current block. Modules
+// can use it to auction what their module account holds, posting a bond is up to the caller.
+func (k Keeper) StartAuction(ctx sdk.Context, auction types.Auction) error {
+	if k.HasAuction(ctx, auction.Lot) {
+		return sdkerrors.Wrap(types.ErrAuctionExist, fmt.Sprintf("Auction %s has existed", auction.Lot))
+	}
+	if !k.router.HasRoute(auction.Asset) {
+		return sdkerrors.Wrap(types.ErrUnknownAsset, auction.Asset)
+	}
+	// all assets share the lot IDs, a lot cannot take the ID of what another asset keeps
+	for _, route := range k.router.Routes() {
+		if route != auction.Asset && k.router.GetRoute(route).Claims(ctx, auction.Lot) {
+			return sdkerrors.Wrap(types.ErrLotClaimed, fmt.Sprintf("Lot %s belongs to asset %s", auction.Lot, route))
+		}
+	}
+	if err := k.router.GetRoute(auction.Asset).Lock(ctx, auction.Lot, auction.LotItems(), auction.Owner); err != nil {
+		return err
+	}
+	auction.Schedule(k.GetParams(ctx), ctx.BlockHeight(), ctx.BlockTime())
+	k.SetAuction(ctx, auction.Lot, auction, false)
+	return nil
+}
+
+// SetAuction stores an auction and keeps its deadline queue entry up to date, do not check in keeper
+func (k Keeper) SetAuction(ctx sdk.Context, lot string, auction types.Auction, isGenesis bool) {
+	if auction.Owner.Empty() && !auction.IsInitial() {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	// only english auctions are extended by bids, the others run on fixed schedules. A timed
+	// auction keeps its end time, only a late bid extends it.
+	if !isGenesis && auction.IsEnglish() && !auction.IsTimed() {
+		auction.Deadline = ctx.BlockHeight() + k.GetParams(ctx).AuctionPeriod
+	}
+	if k.HasAuction(ctx, lot) {
+		store.Delete(auctionQueueKey(k.GetAuction(ctx, lot)))
+	}
+	store.Set(util.AuctionKey(lot), k.cdc.MustMarshalBinaryBare(auction))
+	store.Set(auctionQueueKey(auction), []byte(lot))
+}
+
+// auctionQueueKey returns the entry of an auction in the height or the time queue
+func auctionQueueKey(auction types.Auction) []byte {
+	if auction.IsTimed() {
+		return util.AuctionTimeQueueKey(auction.EndTimestamp(), auction.Lot)
+	}
+	return util.AuctionQueueKey(auction.EndHeight(), auction.Lot)
+}
+
+// UpdateAuction stores changes to a running auction without moving its deadline
+func (k Keeper) UpdateAuction(ctx sdk.Context, auction types.Auction) {
+	k.SetAuction(ctx, auction.Lot, auction, true)
+}
+
+func (k Keeper) GetAuction(ctx sdk.Context, lot string) types.Auction {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(util.AuctionKey(lot))
+	if bz == nil {
+		return types.NewAuction()
+	}
+	var auction types.Auction
+	k.cdc.MustUnmarshalBinaryBare(bz, &auction)
+	return auction
+}
+
+func (k Keeper) HasAuction(ctx sdk.Context, lot string) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(util.AuctionKey(lot))
+}
+
+func (k Keeper) DeleteAuction(ctx sdk.Context, lot string) {
+	store := ctx.KVStore(k.storeKey)
+	auction := k.GetAuction(ctx, lot)
+	for seq := uint64(0); seq < auction.BidCount; seq++ {
+		store.Delete(util.BidKey(lot, seq))
+	}
+	store.Delete(auctionQueueKey(auction))
+	store.Delete(util.AuctionKey(lot))
+}
+
+// SetBid adds a bid to the bid book and makes it the current high bid, do not check in keeper
+func (k Keeper) SetBid(ctx sdk.Context, lot string, price sdk.Coins, bidder sdk.AccAddress) {
+	k.SetProxyBid(ctx, lot, price, bidder, nil)
+}
+
+// SetProxyBid is SetBid for a proxy bid, maxPrice is the hidden maximum the bid may be raised to
+func (k Keeper) SetProxyBid(ctx sdk.Context, lot string, price sdk.Coins, bidder sdk.AccAddress, maxPrice sdk.Coins) {
+	auction := k.GetAuction(ctx, lot)
+	k.appendBid(ctx, &auction, price, bidder)
+	auction.BidPrice = price
+	auction.Bidder = bidder
+	auction.ProxyMax = maxPrice
+	k.SetAuction(ctx, lot, auction, false)
+}
+
+// RecordBid adds a bid to the bid book without making it the high bid, used for bids a proxy
+// bid has answered
+func (k Keeper) RecordBid(ctx sdk.Context, lot string, price sdk.Coins, bidder sdk.AccAddress) {
+	auction := k.GetAuction(ctx, lot)
+	k.appendBid(ctx, &auction, price, bidder)
+	k.SetAuction(ctx, lot, auction, false)
+}
+
+func (k Keeper) appendBid(ctx sdk.Context, auction *types.Auction, price sdk.Coins, bidder sdk.AccAddress) {
+	bid := types.Bid{Lot: auction.Lot, Bidder: bidder, Price: price, Height: ctx.BlockHeight()}
+	k.SetBidAt(ctx, auction.BidCount, bid)
+	auction.BidCount++
+	// a bid landing within AuctionDuration of the end of a timed english auction pushes the end
+	// back, so the other bidders get AuctionDuration to answer
+	if auction.IsEnglish() && auction.IsTimed() {
+		if end := ctx.BlockTime().Add(k.GetParams(ctx).AuctionDuration); end.After(auction.EndTime) {
+			auction.EndTime = end
+		}
+	}
+}
+
+// SetBidAt stores a bid at its position in the bid book of its lot, the BidCount of the auction
+// is left as is
+func (k Keeper) SetBidAt(ctx sdk.Context, seq uint64, bid types.Bid) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(util.BidKey(bid.Lot, seq), k.cdc.MustMarshalBinaryBare(bid))
+}
+
+// GetBids returns the bid book of a lot, oldest and lowest bid first
+func (k Keeper) GetBids(ctx sdk.Context, lot string) []types.Bid {
+	store := ctx.KVStore(k.storeKey)
+	auction := k.GetAuction(ctx, lot)
+	bids := make([]types.Bid, 0, auction.BidCount)
+	for seq := uint64(0); seq < auction.BidCount; seq++ {
+		var bid types.Bid
+		k.cdc.MustUnmarshalBinaryBare(store.Get(util.BidKey(lot, seq)), &bid)
+		bids = append(bids, bid)
+	}
+	return bids
+}
+
+func (k Keeper) GetAuctionOwner(ctx sdk.Context, lot string) sdk.AccAddress {
+	return k.GetAuction(ctx, lot).Owner
+}
+
+func (k Keeper) GetBid(ctx sdk.Context, lot string) sdk.Coins {
+	return k.GetAuction(ctx, lot).BidPrice
+}
+
+func (k Keeper) ResolveBidder(ctx sdk.Context, lot string) string {
+	return k.GetAuction(ctx, lot).Bidder.String()
+}
+
+func (k Keeper) GetDeadline(ctx sdk.Context, lot string) int64 {
+	return k.GetAuction(ctx, lot).Deadline
+}
+
+func (k Keeper) GetAuctionIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, util.AuctionPrefix)
+}
+
+// GetAuctions returns every open auction
+func (k Keeper) GetAuctions(ctx sdk.Context) []types.Auction {
+	var auctions []types.Auction
+	iterator := k.GetAuctionIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var auction types.Auction
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &auction)
+		auctions = append(auctions, auction)
+	}
+	return auctions
+}
+
+// ArchiveAuction removes a completed auction and keeps its outcome in the archive
+func (k Keeper) ArchiveAuction(ctx sdk.Context, auction types.Auction, outcome types.AuctionOutcome,
+	winner sdk.AccAddress, price sdk.Coins) uint64 {
+	id := k.GetSettledAuctionCount(ctx)
+	settled := types.SettledAuction{
+		ID:            id,
+		Auction:       auction,
+		Outcome:       outcome,
+		Winner:        winner,
+		Price:         price,
+		SettledHeight: ctx.BlockHeight(),
+	}
+	k.DeleteAuction(ctx, auction.Lot)
+	k.SetSettledAuction(ctx, settled)
+	k.SetSettledAuctionCount(ctx, id+1)
+	return id
+}
+
+// SetSettledAuction stores an auction in the archive under its ID
+func (k Keeper) SetSettledAuction(ctx sdk.Context, settled types.SettledAuction) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(util.SettledAuctionKey(settled.ID), k.cdc.MustMarshalBinaryBare(settled))
+}
+
+// GetSettledAuctionCount returns the ID the next archived auction gets
+func (k Keeper) GetSettledAuctionCount(ctx sdk.Context) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(util.SettledAuctionCountKey)
+	if bz == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// SetSettledAuctionCount sets the ID the next archived auction gets
+func (k Keeper) SetSettledAuctionCount(ctx sdk.Context, count uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(util.SettledAuctionCountKey, sdk.Uint64ToBigEndian(count))
+}
+
+func (k Keeper) GetSettledAuction(ctx sdk.Context, id uint64) (types.SettledAuction, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(util.SettledAuctionKey(id))
+	if bz == nil {
+		return types.SettledAuction{}, false
+	}
+	var settled types.SettledAuction
+	k.cdc.MustUnmarshalBinaryBare(bz, &settled)
+	return settled, true
+}
+
+// GetSettledAuctionsIterator iterates over the archive, most recent auction first
+func (k Keeper) GetSettledAuctionsIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStoreReversePrefixIterator(store, util.SettledAuctionPrefix)
+}
+
+// AuctionQueueIterator iterates in deadline order over the queued auctions ending at or before endHeight
+func (k Keeper) AuctionQueueIterator(ctx sdk.Context, endHeight int64) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return store.Iterator(util.AuctionQueuePrefix, sdk.PrefixEndBytes(util.AuctionQueueHeightKey(endHeight)))
+}
+
+// AuctionTimeQueueIterator iterates in end time order over the queued timed auctions ending before endTime
+func (k Keeper) AuctionTimeQueueIterator(ctx sdk.Context, endTime time.Time) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return store.Iterator(util.AuctionTimeQueuePrefix, util.AuctionTimeQueueTimeKey(endTime))
+}
+
+// GetDueAuctions returns at most limit auctions that are over at the given height and block time,
+// auctions ending at a height first
+func (k Keeper) GetDueAuctions(ctx sdk.Context, height int64, now time.Time, limit int) []types.Auction {
+	var auctions []types.Auction
+	for _, iterator := range []sdk.Iterator{k.AuctionQueueIterator(ctx, height-1), k.AuctionTimeQueueIterator(ctx, now)} {
+		for ; iterator.Valid() && len(auctions) < limit; iterator.Next() {
+			auctions = append(auctions, k.GetAuction(ctx, string(iterator.Value())))
+		}
+		iterator.Close()
+	}
+	return auctions
+}
+
+// EscrowCoins moves coins from an account into the module account until the auction settles
+func (k Keeper) EscrowCoins(ctx sdk.Context, from sdk.AccAddress, amt sdk.Coins) error {
+	if amt.IsZero() {
+		return nil
+	}
+	return k.supplyKeeper.SendCoinsFromAccountToModule(ctx, from, types.ModuleName, amt)
+}
+
+// ReleaseCoins pays escrowed coins out of the module account
+func (k Keeper) ReleaseCoins(ctx sdk.Context, to sdk.AccAddress, amt sdk.Coins) error {
+	if amt.IsZero() {
+		return nil
+	}
+	return k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, to, amt)
+}
+
+// CollectFees moves escrowed coins to the fee collector
+func (k Keeper) CollectFees(ctx sdk.Context, amt sdk.Coins) error {
+	if amt.IsZero() {
+		return nil
+	}
+	return k.supplyKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, k.feeCollectorName, amt)
+}
+
+// PayProceeds pays the proceeds of an auction from an account, to the seller or for chain-run
+// auctions to the fee collector
+func (k Keeper) PayProceeds(ctx sdk.Context, auction types.Auction, from sdk.AccAddress, amt sdk.Coins) error {
+	if auction.IsInitial() {
+		return k.supplyKeeper.SendCoinsFromAccountToModule(ctx, from, k.feeCollectorName, amt)
+	}
+	return k.bankKeeper.SendCoins(ctx, from, auction.Owner, amt)
+}
+
+// ReleaseProceeds pays the proceeds of an auction out of escrow
+func (k Keeper) ReleaseProceeds(ctx sdk.Context, auction types.Auction, amt sdk.Coins) error {
+	if auction.IsInitial() {
+		return k.CollectFees(ctx, amt)
+	}
+	return k.ReleaseCoins(ctx, auction.Owner, amt)
+}
+
+func (k Keeper) SetSealedBid(ctx sdk.Context, bid types.SealedBid) {
+	if bid.Bidder.Empty() {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(util.SealedBidKey(bid.Lot, bid.Bidder), k.cdc.MustMarshalBinaryBare(bid))
+}
+
+func (k Keeper) GetSealedBid(ctx sdk.Context, lot string, bidder sdk.AccAddress) types.SealedBid {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(util.SealedBidKey(lot, bidder))
+	if bz == nil {
+		return types.NewSealedBid()
+	}
+	var bid types.SealedBid
+	k.cdc.MustUnmarshalBinaryBare(bz, &bid)
+	return bid
+}
+
+func (k Keeper) HasSealedBid(ctx sdk.Context, lot string, bidder sdk.AccAddress) bool {
+	return !k.GetSealedBid(ctx, lot, bidder).Bidder.Empty()
+}
+
+func (k Keeper) DeleteSealedBid(ctx sdk.Context, lot string, bidder sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(util.SealedBidKey(lot, bidder))
+}
+
+// GetSealedBids returns every sealed bid committed to a lot
+func (k Keeper) GetSealedBids(ctx sdk.Context, lot string) []types.SealedBid {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, util.SealedBidsKey(lot))
+	defer iterator.Close()
+
+	var bids []types.SealedBid
+	for ; iterator.Valid(); iterator.Next() {
+		var bid types.SealedBid
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &bid)
+		bids = append(bids, bid)
+	}
+	return bids
+}
@@ -0,0 +1,72 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rune/baseapp/x/auction/internal/types"
+	"github.com/rune/baseapp/x/auction/util"
+)
+
+// MigrateStore moves the auctions of consensus version 1 out of the nameservice store, where
+// they were kept under "Auction:" next to the names, into the auction store. Only a record that
+// decodes as an auction of the lot its key names is moved, every other key is a name and left to
+// the nameservice migration. It has to run before that migration, which reads every string key
+// left in its store as a name.
+func (k Keeper) MigrateStore(ctx sdk.Context, legacyStoreKey sdk.StoreKey) {
+	from, to := ctx.KVStore(legacyStoreKey), ctx.KVStore(k.storeKey)
+	prefix := []byte(util.LegacyAuctionPrefix)
+
+	// collect first, a store must not be written while it is iterated
+	var keys, values [][]byte
+	iterator := sdk.KVStorePrefixIterator(from, prefix)
+	for ; iterator.Valid(); iterator.Next() {
+		var legacy types.AuctionV1
+		if err := k.cdc.UnmarshalBinaryBare(iterator.Value(), &legacy); err != nil ||
+			legacy.Lot != string(iterator.Key()[len(prefix):]) {
+			continue
+		}
+		keys = append(keys, iterator.Key())
+		values = append(values, iterator.Value())
+	}
+	iterator.Close()
+
+	for i, key := range keys {
+		from.Delete(key)
+		to.Set(util.AuctionKey(string(key[len(prefix):])), values[i])
+	}
+}
+
+// MigrateAuctionEncoding turns the auctions of consensus version 1 into english auctions of the
+// name asset, nameAsset is its route. Each gets its deadline queue entry and its name locked, and
+// its high bid becomes the bid book, so it settles at its deadline like any unescrowed auction.
+// An auction whose name changed hands while it ran is archived as voided. It reads the single
+// byte layout, so it runs after both store migrations.
+func (k Keeper) MigrateAuctionEncoding(ctx sdk.Context, nameAsset string) error {
+	store := ctx.KVStore(k.storeKey)
+
+	var auctions []types.AuctionV1
+	iterator := sdk.KVStorePrefixIterator(store, util.AuctionPrefix)
+	for ; iterator.Valid(); iterator.Next() {
+		var legacy types.AuctionV1
+		if err := k.cdc.UnmarshalBinaryBare(iterator.Value(), &legacy); err != nil {
+			iterator.Close()
+			return err
+		}
+		auctions = append(auctions, legacy)
+	}
+	iterator.Close()
+
+	asset := k.router.GetRoute(nameAsset)
+	for _, legacy := range auctions {
+		store.Delete(util.AuctionKey(legacy.Lot))
+		auction := legacy.Migrate(nameAsset)
+		if err := asset.Lock(ctx, auction.Lot, auction.LotItems(), auction.Owner); err != nil {
+			k.ArchiveAuction(ctx, auction, types.OutcomeVoided, nil, nil)
+			continue
+		}
+		if !auction.Bidder.Empty() && !auction.Bidder.Equals(auction.Owner) {
+			k.appendBid(ctx, &auction, auction.BidPrice, auction.Bidder)
+		}
+		k.SetAuction(ctx, auction.Lot, auction, true)
+	}
+	return nil
+}
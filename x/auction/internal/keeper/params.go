@@ -0,0 +1,29 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rune/baseapp/x/auction/internal/types"
+)
+
+// GetParams returns the total set of auction parameters.
+func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
+	k.paramspace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the auction parameters to the param space.
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramspace.SetParamSet(ctx, &params)
+}
+
+// MigrateParams adds the params missing from the store with their default values, the stored
+// params are kept
+func (k Keeper) MigrateParams(ctx sdk.Context) {
+	params := types.DefaultParams()
+	for _, pair := range params.ParamSetPairs() {
+		if k.paramspace.Has(ctx, pair.Key) {
+			k.paramspace.Get(ctx, pair.Key, pair.Value)
+		}
+	}
+	k.SetParams(ctx, params)
+}
@@ -0,0 +1,175 @@
+package keeper
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/rune/baseapp/x/auction/internal/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// query endpoints supported by the auction Querier
+const (
+	QueryAuctions = "auctions"
+	QueryAuction  = "auction"
+	QueryBids     = "bids"
+
+	QuerySettledAuctions = "settled-auctions"
+	QuerySettledAuction  = "settled-auction"
+
+	QueryParams = "params"
+)
+
+// NewQuerier is the module level router for state queries
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err error) {
+		switch path[0] {
+		case QueryAuctions:
+			return queryAuctions(ctx, keeper)
+		case QueryAuction:
+			return queryAuction(ctx, path[1:], keeper)
+		case QueryBids:
+			return queryBids(ctx, path[1:], keeper)
+		case QuerySettledAuctions:
+			return querySettledAuctions(ctx, req, keeper)
+		case QuerySettledAuction:
+			return querySettledAuction(ctx, path[1:], keeper)
+		case QueryParams:
+			return queryParams(ctx, keeper)
+		default:
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown auction query endpoint")
+		}
+	}
+}
+
+func queryAuctions(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	var auctionList types.QueryAuctions
+	iterator := keeper.GetAuctionIterator(ctx)
+	for ; iterator.Valid(); iterator.Next() {
+		value := iterator.Value()
+		var auction types.Auction
+		_ = types.ModuleCdc.UnmarshalBinaryBare(value, &auction)
+		auctionList = append(auctionList, describeAuction(ctx, keeper, auction))
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, auctionList)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryAuction(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	lot := path[0]
+	var msg string
+	if keeper.HasAuction(ctx, lot) {
+		auction := keeper.GetAuction(ctx, lot)
+		msg = describeAuction(ctx, keeper, auction)
+		if auction.IsDutch() {
+			msg = fmt.Sprintf("%s Current Price: %s", msg, auction.CurrentPrice(ctx.BlockHeight()))
+		}
+	} else {
+		msg = fmt.Sprintf("No auction %s is processing", lot)
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, msg)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+// describeAuction adds the end height and end time of an auction, whichever is not its deadline is estimated
+func describeAuction(ctx sdk.Context, keeper Keeper, auction types.Auction) string {
+	height, end := auction.EstimateEnd(ctx.BlockHeight(), ctx.BlockTime(), keeper.GetParams(ctx).ExpectedBlockTime)
+	if auction.IsTimed() {
+		return fmt.Sprintf("%s End Time: %s Estimated End Height: %d", auction, end.Format(time.RFC3339), height)
+	}
+	return fmt.Sprintf("%s Estimated End Time: %s End Height: %d", auction, end.Format(time.RFC3339), height)
+}
+
+func queryBids(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	lot := path[0]
+	if !keeper.HasAuction(ctx, lot) {
+		return nil, sdkerrors.Wrap(types.ErrAuctionDoesNotExist, lot)
+	}
+	res, err := codec.MarshalJSONIndent(keeper.cdc, types.QueryResBids(keeper.GetBids(ctx, lot)))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func querySettledAuctions(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, error) {
+	var params types.QuerySettledAuctionsParams
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.Limit < 1 {
+		params.Limit = 100
+	}
+	skip := (params.Page - 1) * params.Limit
+
+	settledList := types.QueryResSettledAuctions{}
+	iterator := keeper.GetSettledAuctionsIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid() && len(settledList) < params.Limit; iterator.Next() {
+		var settled types.SettledAuction
+		keeper.cdc.MustUnmarshalBinaryBare(iterator.Value(), &settled)
+		if !params.Seller.Empty() && !params.Seller.Equals(settled.Auction.Owner) {
+			continue
+		}
+		// auctions archived before insufficient_funds kept an empty winner still name the bidder that did not pay
+		if !params.Winner.Empty() && (settled.Outcome != types.OutcomeSold || !params.Winner.Equals(settled.Winner)) {
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		settledList = append(settledList, settled)
+	}
+
+	res, err := codec.MarshalJSONIndent(keeper.cdc, settledList)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func querySettledAuction(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	id, err := strconv.ParseUint(path[0], 10, 64)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, fmt.Sprintf("invalid auction id %s", path[0]))
+	}
+	settled, found := keeper.GetSettledAuction(ctx, id)
+	if !found {
+		return nil, sdkerrors.Wrap(types.ErrSettledAuctionNotExist, path[0])
+	}
+
+	res, err := codec.MarshalJSONIndent(keeper.cdc, settled)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
+
+func queryParams(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetParams(ctx))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
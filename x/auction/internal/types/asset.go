@@ -0,0 +1,99 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Asset is implemented by the module owning what an auction sells. Items name the parts of a
+// lot in the terms of the asset, e.g. names for the nameservice.
+type Asset interface {
+	// Lock checks owner may sell the items and keeps them from being used until the auction
+	// ends. The lot of a chain-run auction has no owner.
+	Lock(ctx sdk.Context, lot string, items []string, owner sdk.AccAddress) error
+	// Transfer hands the items of a sold lot to the winner and releases them
+	Transfer(ctx sdk.Context, items []string, owner, winner sdk.AccAddress, price sdk.Coins) error
+	// Unlock releases the items of a lot that was not sold
+	Unlock(ctx sdk.Context, items []string, owner sdk.AccAddress) error
+	// Locked checks the items of an open lot are still locked for it and held by owner. It
+	// returns what the asset keeps in the auction module account for them.
+	Locked(ctx sdk.Context, lot string, items []string, owner sdk.AccAddress) (sdk.Coins, error)
+	// Claims reports whether lot is the ID of something the asset keeps, e.g. a registered
+	// name. Auctions of other assets cannot use it as their lot.
+	Claims(ctx sdk.Context, lot string) bool
+}
+
+var _ AssetRouter = (*assetRouter)(nil)
+
+// AssetRouter routes auctions to the asset they sell
+type AssetRouter interface {
+	AddRoute(r string, a Asset) (rtr AssetRouter)
+	HasRoute(r string) bool
+	GetRoute(path string) (a Asset)
+	Routes() []string
+	Seal()
+}
+
+type assetRouter struct {
+	routes map[string]Asset
+	sealed bool
+}
+
+// NewAssetRouter creates a new AssetRouter interface instance
+func NewAssetRouter() AssetRouter {
+	return &assetRouter{
+		routes: make(map[string]Asset),
+	}
+}
+
+// Seal seals the router which prohibits any subsequent assets to be added. Seal will panic if
+// called more than once.
+func (rtr *assetRouter) Seal() {
+	if rtr.sealed {
+		panic("asset router already sealed")
+	}
+	rtr.sealed = true
+}
+
+// AddRoute adds an asset for a given path. It returns the AssetRouter so AddRoute calls can be
+// linked. It will panic if the router is sealed.
+func (rtr *assetRouter) AddRoute(path string, a Asset) AssetRouter {
+	if rtr.sealed {
+		panic("asset router sealed; cannot add asset")
+	}
+	if !sdk.IsAlphaNumeric(path) {
+		panic("route expressions can only contain alphanumeric characters")
+	}
+	if rtr.HasRoute(path) {
+		panic(fmt.Sprintf("route %s has already been initialized", path))
+	}
+
+	rtr.routes[path] = a
+	return rtr
+}
+
+// HasRoute returns true if the router has a path registered or false otherwise.
+func (rtr *assetRouter) HasRoute(path string) bool {
+	return rtr.routes[path] != nil
+}
+
+// GetRoute returns the Asset for a given path.
+func (rtr *assetRouter) GetRoute(path string) Asset {
+	if !rtr.HasRoute(path) {
+		panic(fmt.Sprintf("route \"%s\" does not exist", path))
+	}
+
+	return rtr.routes[path]
+}
+
+// Routes returns the paths of the registered assets in sorted order
+func (rtr *assetRouter) Routes() []string {
+	routes := make([]string, 0, len(rtr.routes))
+	for path := range rtr.routes {
+		routes = append(routes, path)
+	}
+	sort.Strings(routes)
+	return routes
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// RegisterCodec registers concrete types on codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgAuction{}, "auction/Auction", nil)
+	cdc.RegisterConcrete(MsgBid{}, "auction/Bid", nil)
+	cdc.RegisterConcrete(MsgCommitBid{}, "auction/CommitBid", nil)
+	cdc.RegisterConcrete(MsgRevealBid{}, "auction/RevealBid", nil)
+	cdc.RegisterConcrete(MsgCancelAuction{}, "auction/CancelAuction", nil)
+	cdc.RegisterConcrete(MsgUpdateAllowlist{}, "auction/UpdateAllowlist", nil)
+}
+
+// ModuleCdc defines the module codec
+var ModuleCdc *codec.Codec
+
+func init() {
+	ModuleCdc = codec.New()
+	RegisterCodec(ModuleCdc)
+	codec.RegisterCrypto(ModuleCdc)
+	ModuleCdc.Seal()
+}
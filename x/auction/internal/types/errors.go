@@ -0,0 +1,19 @@
+package types
+
+import sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+var (
+	ErrAuctionExist           = sdkerrors.Register(ModuleName, 1, "auction exists")
+	ErrAuctionDoesNotExist    = sdkerrors.Register(ModuleName, 2, "auction does not exist")
+	ErrBidPriceTooLow         = sdkerrors.Register(ModuleName, 3, "bid price is too low")
+	ErrInvalidAuctionType     = sdkerrors.Register(ModuleName, 4, "invalid auction type")
+	ErrAuctionPhase           = sdkerrors.Register(ModuleName, 5, "auction is not in the required phase")
+	ErrSealedBidExist         = sdkerrors.Register(ModuleName, 6, "sealed bid exists")
+	ErrSealedBidNotExist      = sdkerrors.Register(ModuleName, 7, "sealed bid does not exist")
+	ErrInvalidReveal          = sdkerrors.Register(ModuleName, 8, "revealed bid does not match commitment")
+	ErrSettledAuctionNotExist = sdkerrors.Register(ModuleName, 9, "settled auction does not exist")
+	ErrBidderNotAllowed       = sdkerrors.Register(ModuleName, 10, "bidder is not on the auction allowlist")
+	ErrUnknownAsset           = sdkerrors.Register(ModuleName, 11, "unknown asset")
+	ErrInitialTerms           = sdkerrors.Register(ModuleName, 12, "invalid terms for a chain-run auction")
+	ErrLotClaimed             = sdkerrors.Register(ModuleName, 13, "lot belongs to another asset")
+)
@@ -0,0 +1,36 @@
+package types
+
+// GenesisState holds the open auctions with their bid books and sealed bids, and the archive.
+// The deadline queues are rebuilt from the auctions.
+type GenesisState struct {
+	Params         Params    `json:"params"`
+	AuctionRecords []Auction `json:"auction_records"`
+	// Bids are the bid books of the open auctions, each lot in the order its bids arrived
+	Bids       []Bid       `json:"bids"`
+	SealedBids []SealedBid `json:"sealed_bids"`
+	// SettledAuctions is the archive, SettledAuctionCount the ID of the next archived auction
+	SettledAuctions     []SettledAuction `json:"settled_auctions"`
+	SettledAuctionCount uint64           `json:"settled_auction_count"`
+}
+
+func NewGenesisState(params Params, auctionRecords []Auction, bids []Bid, sealedBids []SealedBid,
+	settledAuctions []SettledAuction, settledAuctionCount uint64) GenesisState {
+	return GenesisState{
+		Params:              params,
+		AuctionRecords:      auctionRecords,
+		Bids:                bids,
+		SealedBids:          sealedBids,
+		SettledAuctions:     settledAuctions,
+		SettledAuctionCount: settledAuctionCount,
+	}
+}
+
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Params:          DefaultParams(),
+		AuctionRecords:  []Auction{},
+		Bids:            []Bid{},
+		SealedBids:      []SealedBid{},
+		SettledAuctions: []SettledAuction{},
+	}
+}
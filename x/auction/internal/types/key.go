@@ -0,0 +1,18 @@
+package types
+
+const (
+	// ModuleName is the name of the module
+	ModuleName = "auction"
+
+	// StoreKey to be used when creating the KVStore
+	StoreKey = ModuleName
+
+	// RouterKey to be used for routing msgs
+	RouterKey = ModuleName
+
+	// QuerierRoute to be used for querierer msgs
+	QuerierRoute = ModuleName
+)
+
+// CoinsAssetRoute is the route coins are auctioned under
+const CoinsAssetRoute = "coins"
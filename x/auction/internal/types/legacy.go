@@ -0,0 +1,26 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AuctionV1 is the encoding of an auction at nameservice consensus version 1, when a name was
+// auctioned under its own name in the nameservice store. It had no bid book, no bond and no
+// name lock, the high bidder paid at settlement. Only the store migration reads it.
+type AuctionV1 struct {
+	Lot          string         `json:"lot"`
+	Owner        sdk.AccAddress `json:"owner"`
+	ReservePrice sdk.Coins      `json:"reserve_price"`
+	Bidder       sdk.AccAddress `json:"bidder"`
+	BidPrice     sdk.Coins      `json:"bid_price"`
+	Deadline     int64          `json:"deadline"`
+}
+
+// Migrate returns the auction as an english auction of the name sold, asset is the route of the
+// name asset. The high bid still has to be added to the bid book.
+func (a AuctionV1) Migrate(asset string) Auction {
+	return Auction{
+		Lot: a.Lot, Owner: a.Owner, ReservePrice: a.ReservePrice, Bidder: a.Bidder,
+		BidPrice: a.BidPrice, Deadline: a.Deadline, Type: AuctionTypeEnglish, Asset: asset,
+	}
+}
@@ -0,0 +1,402 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// MsgAuction starts an auction selling a lot of an asset
+type MsgAuction struct {
+	Asset        string         `json:"asset"`
+	Lot          string         `json:"lot"`
+	Owner        sdk.AccAddress `json:"owner"`
+	ReservePrice sdk.Coins      `json:"reserve_price"`
+	AuctionType  AuctionType    `json:"auction_type"`
+	// StartPrice and PriceStep are only used by dutch auctions
+	StartPrice sdk.Coins `json:"start_price"`
+	PriceStep  sdk.Coins `json:"price_step"`
+	// BuyNowPrice is optional and only used by english auctions
+	BuyNowPrice sdk.Coins `json:"buy_now_price"`
+	// Initial opens a chain-run auction for a lot nobody owns, Owner only posts the bond
+	Initial bool `json:"initial"`
+	// Relists is the number of times the auction is listed again when it ends without bids,
+	// every time with the reserve price lowered by ReserveStep but not below FloorPrice
+	Relists     uint64    `json:"relists"`
+	ReserveStep sdk.Coins `json:"reserve_step"`
+	FloorPrice  sdk.Coins `json:"floor_price"`
+	// Allowlist makes the auction private, only the listed accounts can bid
+	Allowlist []sdk.AccAddress `json:"allowlist"`
+	// Items optionally lists what the lot is made of, e.g. several names sold as a bundle. Lot
+	// is then only the auction ID.
+	Items []string `json:"items"`
+	// EndTime optionally ends an english auction, or the commit phase of a sealed-bid auction,
+	// at a UTC block time instead of after a number of blocks
+	EndTime time.Time `json:"end_time"`
+}
+
+func NewMsgAuction(asset string, lot string, owner sdk.AccAddress, price sdk.Coins, auctionType AuctionType) MsgAuction {
+	return MsgAuction{
+		Asset:        asset,
+		Lot:          lot,
+		Owner:        owner,
+		ReservePrice: price,
+		AuctionType:  auctionType,
+	}
+}
+
+// NewMsgDutchAuction creates a dutch auction asking startPrice, lowered by step every block down to reserve
+func NewMsgDutchAuction(asset string, lot string, owner sdk.AccAddress, reserve sdk.Coins, startPrice sdk.Coins, step sdk.Coins) MsgAuction {
+	return MsgAuction{
+		Asset:        asset,
+		Lot:          lot,
+		Owner:        owner,
+		ReservePrice: reserve,
+		AuctionType:  AuctionTypeDutch,
+		StartPrice:   startPrice,
+		PriceStep:    step,
+	}
+}
+
+func (msg MsgAuction) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgAuction) Type() string { return "new_auction" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgAuction) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+	if !sdk.IsAlphaNumeric(msg.Asset) {
+		return sdkerrors.Wrap(ErrUnknownAsset, msg.Asset)
+	}
+	if len(msg.Lot) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "Lot cannot be empty")
+	}
+	if !msg.ReservePrice.IsAllPositive() {
+		return sdkerrors.Wrap(sdkerrors.ErrInsufficientFunds, "Reserve Price is negative")
+	}
+	if !msg.AuctionType.IsValid() {
+		return sdkerrors.Wrap(ErrInvalidAuctionType, string(msg.AuctionType))
+	}
+	if msg.AuctionType == AuctionTypeDutch {
+		if !msg.StartPrice.IsAllGT(msg.ReservePrice) {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "Start Price should be greater than Reserve Price")
+		}
+		if !msg.PriceStep.IsAllPositive() {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "Price Step should be positive")
+		}
+		if len(msg.PriceStep) != len(msg.StartPrice) || !msg.PriceStep.DenomsSubsetOf(msg.StartPrice) {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "Price Step should have the denoms of Start Price")
+		}
+	}
+	if !msg.BuyNowPrice.Empty() {
+		if msg.AuctionType != AuctionTypeEnglish {
+			return sdkerrors.Wrap(ErrInvalidAuctionType, "only english auctions take a Buy Now Price")
+		}
+		if !msg.BuyNowPrice.IsAllGT(msg.ReservePrice) {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "Buy Now Price should be greater than Reserve Price")
+		}
+	}
+	if !msg.EndTime.IsZero() {
+		if msg.AuctionType == AuctionTypeDutch {
+			return sdkerrors.Wrap(ErrInvalidAuctionType, "dutch auctions cannot end at a time")
+		}
+		if msg.EndTime.Location() != time.UTC {
+			return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "End Time should be UTC")
+		}
+	}
+	seen := make(map[string]bool, len(msg.Items))
+	for _, item := range msg.Items {
+		if len(item) == 0 || seen[item] {
+			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid lot item %q", item)
+		}
+		seen[item] = true
+	}
+	if len(msg.Allowlist) != 0 {
+		if msg.Initial {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "chain-run auctions cannot be private")
+		}
+		if err := validateAddresses(msg.Allowlist); err != nil {
+			return err
+		}
+	}
+	if msg.Relists > 0 {
+		if !msg.ReserveStep.IsAllPositive() || !msg.ReserveStep.DenomsSubsetOf(msg.ReservePrice) {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "Reserve Step should be positive and have the denoms of Reserve Price")
+		}
+		if !msg.FloorPrice.IsValid() || !msg.ReservePrice.IsAllGT(msg.FloorPrice) {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "Floor Price should be lower than Reserve Price")
+		}
+	}
+	return nil
+}
+
+func (msg MsgAuction) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgAuction) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+type MsgBid struct {
+	Lot      string         `json:"lot"`
+	Bidder   sdk.AccAddress `json:"bidder"`
+	BidPrice sdk.Coins      `json:"bid_price"`
+	// BuyNow fails the bid instead of placing it when it does not reach the buy-now price
+	BuyNow bool `json:"buy_now"`
+	// MaxPrice optionally makes this a proxy bid, raised automatically up to MaxPrice
+	MaxPrice sdk.Coins `json:"max_price"`
+}
+
+func NewMsgBid(lot string, bidder sdk.AccAddress, price sdk.Coins) MsgBid {
+	return MsgBid{
+		Lot:      lot,
+		Bidder:   bidder,
+		BidPrice: price,
+	}
+}
+
+// NewMsgProxyBid bids price on an english auction and lets the keeper raise it up to maxPrice
+func NewMsgProxyBid(lot string, bidder sdk.AccAddress, price sdk.Coins, maxPrice sdk.Coins) MsgBid {
+	return MsgBid{
+		Lot:      lot,
+		Bidder:   bidder,
+		BidPrice: price,
+		MaxPrice: maxPrice,
+	}
+}
+
+// NewMsgBuyNow bids on an auction only if price covers its buy-now price
+func NewMsgBuyNow(lot string, bidder sdk.AccAddress, price sdk.Coins) MsgBid {
+	return MsgBid{
+		Lot:      lot,
+		Bidder:   bidder,
+		BidPrice: price,
+		BuyNow:   true,
+	}
+}
+
+func (msg MsgBid) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgBid) Type() string { return "bid" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgBid) ValidateBasic() error {
+	if msg.Bidder.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Bidder.String())
+	}
+	if len(msg.Lot) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "Lot cannot be empty")
+	}
+	if !msg.BidPrice.IsAllPositive() {
+		return sdkerrors.Wrap(sdkerrors.ErrInsufficientFunds, "Bid Price is negative")
+	}
+	if !msg.MaxPrice.Empty() && !msg.MaxPrice.IsAllGTE(msg.BidPrice) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "Max Price should not be lower than Bid Price")
+	}
+	return nil
+}
+
+func (msg MsgBid) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgBid) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Bidder}
+}
+
+// MsgCommitBid places a sealed bid, only the hash of the bid is published until the reveal phase
+type MsgCommitBid struct {
+	Lot     string         `json:"lot"`
+	Bidder  sdk.AccAddress `json:"bidder"`
+	Hash    []byte         `json:"hash"`
+	Deposit sdk.Coins      `json:"deposit"`
+}
+
+func NewMsgCommitBid(lot string, bidder sdk.AccAddress, hash []byte, deposit sdk.Coins) MsgCommitBid {
+	return MsgCommitBid{
+		Lot:     lot,
+		Bidder:  bidder,
+		Hash:    hash,
+		Deposit: deposit,
+	}
+}
+
+func (msg MsgCommitBid) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgCommitBid) Type() string { return "commit_bid" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgCommitBid) ValidateBasic() error {
+	if msg.Bidder.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Bidder.String())
+	}
+	if len(msg.Lot) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "Lot cannot be empty")
+	}
+	if len(msg.Hash) != tmhash.Size {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "Hash must be %d bytes", tmhash.Size)
+	}
+	if !msg.Deposit.IsAllPositive() {
+		return sdkerrors.Wrap(sdkerrors.ErrInsufficientFunds, "Deposit is negative")
+	}
+	return nil
+}
+
+func (msg MsgCommitBid) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCommitBid) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Bidder}
+}
+
+// MsgRevealBid opens a sealed bid committed earlier with MsgCommitBid
+type MsgRevealBid struct {
+	Lot      string         `json:"lot"`
+	Bidder   sdk.AccAddress `json:"bidder"`
+	BidPrice sdk.Coins      `json:"bid_price"`
+	Salt     string         `json:"salt"`
+}
+
+func NewMsgRevealBid(lot string, bidder sdk.AccAddress, price sdk.Coins, salt string) MsgRevealBid {
+	return MsgRevealBid{
+		Lot:      lot,
+		Bidder:   bidder,
+		BidPrice: price,
+		Salt:     salt,
+	}
+}
+
+func (msg MsgRevealBid) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgRevealBid) Type() string { return "reveal_bid" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgRevealBid) ValidateBasic() error {
+	if msg.Bidder.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Bidder.String())
+	}
+	if len(msg.Lot) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "Lot cannot be empty")
+	}
+	if !msg.BidPrice.IsAllPositive() {
+		return sdkerrors.Wrap(sdkerrors.ErrInsufficientFunds, "Bid Price is negative")
+	}
+	return nil
+}
+
+func (msg MsgRevealBid) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgRevealBid) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Bidder}
+}
+
+// MsgCancelAuction withdraws an auction, the seller bond is slashed once bids have arrived
+type MsgCancelAuction struct {
+	Lot   string         `json:"lot"`
+	Owner sdk.AccAddress `json:"owner"`
+}
+
+func NewMsgCancelAuction(lot string, owner sdk.AccAddress) MsgCancelAuction {
+	return MsgCancelAuction{
+		Lot:   lot,
+		Owner: owner,
+	}
+}
+
+func (msg MsgCancelAuction) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgCancelAuction) Type() string { return "cancel_auction" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgCancelAuction) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+	if len(msg.Lot) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "Lot cannot be empty")
+	}
+	return nil
+}
+
+func (msg MsgCancelAuction) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgCancelAuction) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// MsgUpdateAllowlist adds and removes bidders of a private auction while it runs
+type MsgUpdateAllowlist struct {
+	Lot    string           `json:"lot"`
+	Owner  sdk.AccAddress   `json:"owner"`
+	Add    []sdk.AccAddress `json:"add"`
+	Remove []sdk.AccAddress `json:"remove"`
+}
+
+func NewMsgUpdateAllowlist(lot string, owner sdk.AccAddress, add []sdk.AccAddress, remove []sdk.AccAddress) MsgUpdateAllowlist {
+	return MsgUpdateAllowlist{
+		Lot:    lot,
+		Owner:  owner,
+		Add:    add,
+		Remove: remove,
+	}
+}
+
+func (msg MsgUpdateAllowlist) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg MsgUpdateAllowlist) Type() string { return "update_allowlist" }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgUpdateAllowlist) ValidateBasic() error {
+	if msg.Owner.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
+	}
+	if len(msg.Lot) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "Lot cannot be empty")
+	}
+	if len(msg.Add) == 0 && len(msg.Remove) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "Nothing to update")
+	}
+	if err := validateAddresses(msg.Add); err != nil {
+		return err
+	}
+	return validateAddresses(msg.Remove)
+}
+
+func (msg MsgUpdateAllowlist) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgUpdateAllowlist) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
+
+// validateAddresses checks a list of addresses holds no empty or repeated address
+func validateAddresses(addrs []sdk.AccAddress) error {
+	seen := make(map[string]bool, len(addrs))
+	for _, addr := range addrs {
+		if addr.Empty() {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "empty address")
+		}
+		if seen[addr.String()] {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "duplicate address %s", addr)
+		}
+		seen[addr.String()] = true
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// Default parameter namespace
+const (
+	DefaultParamspace             = ModuleName
+	DefaultEscrowBids             = false
+	DefaultAuctionPeriod          = int64(100)
+	DefaultSealedCommitPeriod     = int64(100)
+	DefaultSealedRevealPeriod     = int64(50)
+	DefaultAuctionDuration        = 10 * time.Minute
+	DefaultSealedCommitDuration   = 10 * time.Minute
+	DefaultSealedRevealDuration   = 5 * time.Minute
+	DefaultMaxSettlementsPerBlock = uint64(100)
+	DefaultExpectedBlockTime      = 5 * time.Second
+)
+
+var (
+	DefaultAuctionBond            = sdk.Coins{sdk.NewInt64Coin("nametoken", 10)}
+	DefaultMinBidIncrement        = sdk.Coins{sdk.NewInt64Coin("nametoken", 1)}
+	DefaultCancelCompensationRate = sdk.NewDecWithPrec(5, 1)
+	DefaultMinInitialReserve      = sdk.Coins{sdk.NewInt64Coin("nametoken", 100)}
+)
+
+// Parameter store keys
+var (
+	KeyAuctionBond            = []byte("AuctionBond")
+	KeyEscrowBids             = []byte("EscrowBids")
+	KeyMinBidIncrement        = []byte("MinBidIncrement")
+	KeyCancelCompensationRate = []byte("CancelCompensationRate")
+	KeyAuctionPeriod          = []byte("AuctionPeriod")
+	KeySealedCommitPeriod     = []byte("SealedCommitPeriod")
+	KeySealedRevealPeriod     = []byte("SealedRevealPeriod")
+	KeyAuctionDuration        = []byte("AuctionDuration")
+	KeySealedCommitDuration   = []byte("SealedCommitDuration")
+	KeySealedRevealDuration   = []byte("SealedRevealDuration")
+	KeyMaxSettlementsPerBlock = []byte("MaxSettlementsPerBlock")
+	KeyExpectedBlockTime      = []byte("ExpectedBlockTime")
+	KeyMinInitialReserve      = []byte("MinInitialReserve")
+)
+
+// ParamKeyTable for auction module
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// Params - used for initializing default parameter for auction at genesis
+type Params struct {
+	// AuctionBond is posted by the seller when an auction starts and refunded when it ends
+	AuctionBond sdk.Coins `json:"auction_bond" yaml:"auction_bond"`
+	// EscrowBids makes new english auctions escrow bids as they arrive. Without escrow the
+	// winner pays at settlement, and when it cannot the next highest bidder is asked.
+	EscrowBids bool `json:"escrow_bids" yaml:"escrow_bids"`
+	// MinBidIncrement is the step a proxy bid is raised by to stay ahead of a competing bid
+	MinBidIncrement sdk.Coins `json:"min_bid_increment" yaml:"min_bid_increment"`
+	// CancelCompensationRate is the share of a slashed bond paid to the displaced high bidder,
+	// the rest goes to the fee collector
+	CancelCompensationRate sdk.Dec `json:"cancel_compensation_rate" yaml:"cancel_compensation_rate"`
+	// AuctionPeriod is the number of blocks an english auction stays open after its last bid
+	AuctionPeriod int64 `json:"auction_period" yaml:"auction_period"`
+	// SealedCommitPeriod is the number of blocks a sealed-bid auction accepts commitments
+	SealedCommitPeriod int64 `json:"sealed_commit_period" yaml:"sealed_commit_period"`
+	// SealedRevealPeriod is the number of blocks after the commit phase in which bids can be revealed
+	SealedRevealPeriod int64 `json:"sealed_reveal_period" yaml:"sealed_reveal_period"`
+	// AuctionDuration is how long a timed english auction stays open at least after its last bid
+	AuctionDuration time.Duration `json:"auction_duration" yaml:"auction_duration"`
+	// SealedCommitDuration is how long a relisted timed sealed-bid auction accepts commitments
+	SealedCommitDuration time.Duration `json:"sealed_commit_duration" yaml:"sealed_commit_duration"`
+	// SealedRevealDuration is how long bids of a timed sealed-bid auction can be revealed
+	SealedRevealDuration time.Duration `json:"sealed_reveal_duration" yaml:"sealed_reveal_duration"`
+	// MaxSettlementsPerBlock caps the auctions settled in one block, the rest carry over to the next
+	MaxSettlementsPerBlock uint64 `json:"max_settlements_per_block" yaml:"max_settlements_per_block"`
+	// ExpectedBlockTime is the block time assumed to estimate the end time of an auction from
+	// its end height and the other way around
+	ExpectedBlockTime time.Duration `json:"expected_block_time" yaml:"expected_block_time"`
+	// MinInitialReserve is the lowest reserve price of a chain-run auction, so a premium name
+	// cannot be sold on terms its opener picks for itself
+	MinInitialReserve sdk.Coins `json:"min_initial_reserve" yaml:"min_initial_reserve"`
+}
+
+// NewParams creates a new Params object
+func NewParams(
+	auctionBond sdk.Coins, escrowBids bool, minBidIncrement sdk.Coins, cancelCompensationRate sdk.Dec,
+	auctionPeriod, sealedCommitPeriod, sealedRevealPeriod int64,
+	auctionDuration, sealedCommitDuration, sealedRevealDuration time.Duration,
+	maxSettlementsPerBlock uint64, expectedBlockTime time.Duration, minInitialReserve sdk.Coins,
+) Params {
+
+	return Params{
+		AuctionBond:            auctionBond,
+		EscrowBids:             escrowBids,
+		MinBidIncrement:        minBidIncrement,
+		CancelCompensationRate: cancelCompensationRate,
+		AuctionPeriod:          auctionPeriod,
+		SealedCommitPeriod:     sealedCommitPeriod,
+		SealedRevealPeriod:     sealedRevealPeriod,
+		AuctionDuration:        auctionDuration,
+		SealedCommitDuration:   sealedCommitDuration,
+		SealedRevealDuration:   sealedRevealDuration,
+		MaxSettlementsPerBlock: maxSettlementsPerBlock,
+		ExpectedBlockTime:      expectedBlockTime,
+		MinInitialReserve:      minInitialReserve,
+	}
+}
+
+// String implements the stringer interface for Params
+func (p Params) String() string {
+	return fmt.Sprintf(`Auction Params:
+  AuctionBond:            %s
+  EscrowBids:             %t
+  MinBidIncrement:        %s
+  CancelCompensationRate: %s
+  AuctionPeriod:          %d
+  SealedCommitPeriod:     %d
+  SealedRevealPeriod:     %d
+  AuctionDuration:        %s
+  SealedCommitDuration:   %s
+  SealedRevealDuration:   %s
+  MaxSettlementsPerBlock: %d
+  ExpectedBlockTime:      %s
+  MinInitialReserve:      %s`,
+		p.AuctionBond, p.EscrowBids, p.MinBidIncrement, p.CancelCompensationRate,
+		p.AuctionPeriod, p.SealedCommitPeriod, p.SealedRevealPeriod,
+		p.AuctionDuration, p.SealedCommitDuration, p.SealedRevealDuration,
+		p.MaxSettlementsPerBlock, p.ExpectedBlockTime, p.MinInitialReserve)
+}
+
+// ParamSetPairs - Implements params.ParamSet
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		params.NewParamSetPair(KeyAuctionBond, &p.AuctionBond, validateCoins),
+		params.NewParamSetPair(KeyEscrowBids, &p.EscrowBids, validateBool),
+		params.NewParamSetPair(KeyMinBidIncrement, &p.MinBidIncrement, validatePositiveCoins),
+		params.NewParamSetPair(KeyCancelCompensationRate, &p.CancelCompensationRate, validateRate),
+		params.NewParamSetPair(KeyAuctionPeriod, &p.AuctionPeriod, validatePeriod),
+		params.NewParamSetPair(KeySealedCommitPeriod, &p.SealedCommitPeriod, validatePeriod),
+		params.NewParamSetPair(KeySealedRevealPeriod, &p.SealedRevealPeriod, validatePeriod),
+		params.NewParamSetPair(KeyAuctionDuration, &p.AuctionDuration, validateDuration),
+		params.NewParamSetPair(KeySealedCommitDuration, &p.SealedCommitDuration, validateDuration),
+		params.NewParamSetPair(KeySealedRevealDuration, &p.SealedRevealDuration, validateDuration),
+		params.NewParamSetPair(KeyMaxSettlementsPerBlock, &p.MaxSettlementsPerBlock, validateMaxSettlements),
+		params.NewParamSetPair(KeyExpectedBlockTime, &p.ExpectedBlockTime, validateDuration),
+		params.NewParamSetPair(KeyMinInitialReserve, &p.MinInitialReserve, validatePositiveCoins),
+	}
+}
+
+// DefaultParams defines the parameters for this module
+func DefaultParams() Params {
+	return NewParams(
+		DefaultAuctionBond, DefaultEscrowBids, DefaultMinBidIncrement, DefaultCancelCompensationRate,
+		DefaultAuctionPeriod, DefaultSealedCommitPeriod, DefaultSealedRevealPeriod,
+		DefaultAuctionDuration, DefaultSealedCommitDuration, DefaultSealedRevealDuration,
+		DefaultMaxSettlementsPerBlock, DefaultExpectedBlockTime, DefaultMinInitialReserve,
+	)
+}
+
+// Validate checks every parameter
+func (p Params) Validate() error {
+	if err := validateCoins(p.AuctionBond); err != nil {
+		return err
+	}
+	if err := validatePositiveCoins(p.MinBidIncrement); err != nil {
+		return err
+	}
+	if err := validateRate(p.CancelCompensationRate); err != nil {
+		return err
+	}
+	for _, period := range []int64{p.AuctionPeriod, p.SealedCommitPeriod, p.SealedRevealPeriod} {
+		if err := validatePeriod(period); err != nil {
+			return err
+		}
+	}
+	for _, d := range []time.Duration{p.AuctionDuration, p.SealedCommitDuration, p.SealedRevealDuration, p.ExpectedBlockTime} {
+		if err := validateDuration(d); err != nil {
+			return err
+		}
+	}
+	if err := validateMaxSettlements(p.MaxSettlementsPerBlock); err != nil {
+		return err
+	}
+	return validatePositiveCoins(p.MinInitialReserve)
+}
+
+func validateCoins(i interface{}) error {
+	v, ok := i.(sdk.Coins)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if !v.IsValid() {
+		return fmt.Errorf("invalid coins: %s", v)
+	}
+
+	return nil
+}
+
+func validatePositiveCoins(i interface{}) error {
+	if err := validateCoins(i); err != nil {
+		return err
+	}
+	if v := i.(sdk.Coins); v.Empty() {
+		return fmt.Errorf("coins must be positive: %s", v)
+	}
+
+	return nil
+}
+
+func validateBool(i interface{}) error {
+	_, ok := i.(bool)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateRate(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || v.IsNegative() {
+		return fmt.Errorf("rate cannot be negative: %s", v)
+	}
+	if v.GT(sdk.OneDec()) {
+		return fmt.Errorf("rate too large: %s", v)
+	}
+
+	return nil
+}
+
+func validatePeriod(i interface{}) error {
+	v, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("period must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func validateDuration(i interface{}) error {
+	v, ok := i.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("duration must be positive: %s", v)
+	}
+
+	return nil
+}
+
+func validateMaxSettlements(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v == 0 {
+		return fmt.Errorf("max settlements per block must be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package types
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type QueryAuctions []string
+
+func (n QueryAuctions) String() string {
+	return strings.Join(n[:], "\n")
+}
+
+// QuerySettledAuctionsParams selects a page of the auction archive, optionally only
+// auctions of a seller or won by a winner
+type QuerySettledAuctionsParams struct {
+	Page   int            `json:"page"`
+	Limit  int            `json:"limit"`
+	Seller sdk.AccAddress `json:"seller"`
+	Winner sdk.AccAddress `json:"winner"`
+}
+
+func NewQuerySettledAuctionsParams(page, limit int, seller, winner sdk.AccAddress) QuerySettledAuctionsParams {
+	return QuerySettledAuctionsParams{
+		Page:   page,
+		Limit:  limit,
+		Seller: seller,
+		Winner: winner,
+	}
+}
+
+// QueryResSettledAuctions Queries Result Payload for a settled-auctions query
+type QueryResSettledAuctions []SettledAuction
+
+func (s QueryResSettledAuctions) String() string {
+	lines := make([]string, len(s))
+	for i, auction := range s {
+		lines[i] = auction.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// QueryResBids Queries Result Payload for a bids query
+type QueryResBids []Bid
+
+func (b QueryResBids) String() string {
+	lines := make([]string, len(b))
+	for i, bid := range b {
+		lines[i] = bid.String()
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,404 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// AuctionType selects the bidding rules an auction runs under
+type AuctionType string
+
+const (
+	// AuctionTypeEnglish is an open ascending auction, the highest visible bid wins
+	AuctionTypeEnglish AuctionType = "english"
+	// AuctionTypeSealed is a sealed-bid second-price (Vickrey) auction
+	AuctionTypeSealed AuctionType = "sealed"
+	// AuctionTypeDutch starts high and declines every block, the first bid at the current price wins
+	AuctionTypeDutch AuctionType = "dutch"
+)
+
+// IsValid reports whether t is a known auction type
+func (t AuctionType) IsValid() bool {
+	switch t {
+	case AuctionTypeEnglish, AuctionTypeSealed, AuctionTypeDutch:
+		return true
+	}
+	return false
+}
+
+// Auction sells a lot of an asset. Lot is the auction ID, the asset named by Asset is told
+// which of its items are sold.
+type Auction struct {
+	// The first fields keep the order of the original name auction, amino numbers the fields
+	// by their position. New fields are appended at the end.
+	Lot          string         `json:"lot"`
+	Owner        sdk.AccAddress `json:"owner"`
+	ReservePrice sdk.Coins      `json:"reserve_price"`
+	Bidder       sdk.AccAddress `json:"bidder"`
+	BidPrice     sdk.Coins      `json:"bid_price"`
+	Deadline     int64          `json:"deadline"`
+	Type         AuctionType    `json:"type"`
+	// RevealDeadline is the last height sealed bids can be revealed, Deadline closes the commit phase
+	RevealDeadline int64 `json:"reveal_deadline"`
+	// StartPrice, PriceStep and StartHeight describe the price curve of a dutch auction
+	StartPrice  sdk.Coins `json:"start_price"`
+	PriceStep   sdk.Coins `json:"price_step"`
+	StartHeight int64     `json:"start_height"`
+	// Bond is the seller bond escrowed for the auction
+	Bond sdk.Coins `json:"bond"`
+	// Escrowed is set when the current high bid is held in escrow
+	Escrowed bool `json:"escrowed"`
+	// ProxyMax is the hidden maximum of the current high bid when it is a proxy bid, it is
+	// escrowed and the visible BidPrice is raised up to it. String never shows it.
+	ProxyMax sdk.Coins `json:"proxy_max"`
+	// BidCount is the number of bids in the bid book
+	BidCount uint64 `json:"bid_count"`
+	// BuyNowPrice optionally ends an english auction right away for a bid reaching it
+	BuyNowPrice sdk.Coins `json:"buy_now_price"`
+	// Opener opened a chain-run auction for a lot nobody owns and posted its bond. Such an
+	// auction has no Owner, its proceeds go to the fee collector.
+	Opener sdk.AccAddress `json:"opener"`
+	// Relists is the number of automatic relistings left when the auction ends without bids,
+	// each lowers the reserve price by ReserveStep down to FloorPrice
+	Relists     uint64    `json:"relists"`
+	ReserveStep sdk.Coins `json:"reserve_step"`
+	FloorPrice  sdk.Coins `json:"floor_price"`
+	// Private auctions only take bids from the accounts on Allowlist, the seller can change
+	// the list while the auction runs
+	Private   bool             `json:"private"`
+	Allowlist []sdk.AccAddress `json:"allowlist"`
+	// Items are what the lot is made of in the terms of its asset, e.g. the names of a bundle.
+	// Without items the auction ID is the only item.
+	Items []string `json:"items"`
+	// EndTime and RevealEndTime replace the deadline heights of a timed auction, they are
+	// compared against the block time
+	EndTime       time.Time `json:"end_time"`
+	RevealEndTime time.Time `json:"reveal_end_time"`
+	// Asset is the route of the asset the lot is made of
+	Asset string `json:"asset"`
+}
+
+func NewAuction() Auction {
+	return Auction{}
+}
+
+// IsInitial reports whether the auction is chain-run
+func (a Auction) IsInitial() bool {
+	return !a.Opener.Empty()
+}
+
+// BondPayer returns the account the seller bond is refunded to
+func (a Auction) BondPayer() sdk.AccAddress {
+	if a.IsInitial() {
+		return a.Opener
+	}
+	return a.Owner
+}
+
+// HeldEscrow returns what is escrowed for the current high bid
+func (a Auction) HeldEscrow() sdk.Coins {
+	if !a.ProxyMax.Empty() {
+		return a.ProxyMax
+	}
+	if a.Escrowed {
+		return a.BidPrice
+	}
+	return nil
+}
+
+// OffersBuyNow reports whether a bid can still end the auction at its buy-now price. Once a
+// proxy answer brought the high bid to the buy-now price, the auction only takes higher bids.
+func (a Auction) OffersBuyNow() bool {
+	return !a.BuyNowPrice.Empty() && !a.BidPrice.IsAllGTE(a.BuyNowPrice)
+}
+
+// LotItems returns the items the auction sells
+func (a Auction) LotItems() []string {
+	if len(a.Items) != 0 {
+		return a.Items
+	}
+	return []string{a.Lot}
+}
+
+// IsAllowed reports whether an account may bid on the auction
+func (a Auction) IsAllowed(bidder sdk.AccAddress) bool {
+	if !a.Private {
+		return true
+	}
+	for _, addr := range a.Allowlist {
+		if addr.Equals(bidder) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextReserve returns the reserve price of the next automatic relisting. It is false when no
+// relisting is left or the reserve price already reached the floor.
+func (a Auction) NextReserve() (sdk.Coins, bool) {
+	if a.Relists == 0 || !a.ReservePrice.IsAllGT(a.FloorPrice) {
+		return nil, false
+	}
+	reserve, negative := a.ReservePrice.SafeSub(a.ReserveStep)
+	if negative || !reserve.IsAllGTE(a.FloorPrice) {
+		reserve = a.FloorPrice
+	}
+	if !reserve.IsAllPositive() {
+		return nil, false
+	}
+	return reserve, true
+}
+
+// IsEnglish reports whether the auction takes open ascending bids. Auctions without a type are english.
+func (a Auction) IsEnglish() bool {
+	return a.Type == AuctionTypeEnglish || a.Type == ""
+}
+
+// IsSealed reports whether the auction takes sealed bids
+func (a Auction) IsSealed() bool {
+	return a.Type == AuctionTypeSealed
+}
+
+// IsDutch reports whether the auction price declines every block
+func (a Auction) IsDutch() bool {
+	return a.Type == AuctionTypeDutch
+}
+
+// CurrentPrice returns the asking price of a dutch auction at the given height. The price drops
+// by PriceStep every block after StartHeight and never goes below the reserve price.
+func (a Auction) CurrentPrice(height int64) sdk.Coins {
+	elapsed := height - a.StartHeight
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	drop := sdk.NewCoins()
+	for _, step := range a.PriceStep {
+		drop = drop.Add(sdk.NewCoin(step.Denom, step.Amount.MulRaw(elapsed)))
+	}
+	price, negative := a.StartPrice.SafeSub(drop)
+	if negative || !price.IsAllGTE(a.ReservePrice) {
+		return a.ReservePrice
+	}
+	return price
+}
+
+// FloorHeight returns the first height at which a dutch auction asks its reserve price
+func (a Auction) FloorHeight() int64 {
+	var blocks int64
+	for _, step := range a.PriceStep {
+		gap := a.StartPrice.AmountOf(step.Denom).Sub(a.ReservePrice.AmountOf(step.Denom))
+		if !gap.IsPositive() {
+			continue
+		}
+		// round up, the price has to fall all the way to the reserve
+		n := gap.Add(step.Amount).SubRaw(1).Quo(step.Amount)
+		if !n.IsInt64() {
+			return math.MaxInt64 / 2
+		}
+		if n.Int64() > blocks {
+			blocks = n.Int64()
+		}
+	}
+	return a.StartHeight + blocks
+}
+
+// EndHeight returns the last height at which the auction is still open
+func (a Auction) EndHeight() int64 {
+	if a.IsSealed() {
+		return a.RevealDeadline
+	}
+	return a.Deadline
+}
+
+// Schedule sets the deadlines of an auction starting at the given height and block time. English
+// deadlines are set by the keeper as they move with every bid. A timed auction keeps an end
+// time still ahead, a relisted one runs for another duration.
+func (a *Auction) Schedule(params Params, height int64, now time.Time) {
+	switch {
+	case a.IsEnglish() && a.IsTimed():
+		if !a.EndTime.After(now) {
+			a.EndTime = now.Add(params.AuctionDuration)
+		}
+	case a.IsSealed() && a.IsTimed():
+		if !a.EndTime.After(now) {
+			a.EndTime = now.Add(params.SealedCommitDuration)
+		}
+		a.RevealEndTime = a.EndTime.Add(params.SealedRevealDuration)
+	case a.IsSealed():
+		a.Deadline = height + params.SealedCommitPeriod
+		a.RevealDeadline = a.Deadline + params.SealedRevealPeriod
+	case a.IsDutch():
+		a.StartHeight = height
+		// once the price reaches the reserve it is offered for one more auction period
+		a.Deadline = a.FloorHeight() + params.AuctionPeriod
+	}
+}
+
+// Rebase moves the heights of the auction back by height, e.g. for a zero-height export. The
+// deadlines of a timed auction are block times and are kept.
+func (a *Auction) Rebase(height int64) {
+	switch {
+	case a.IsDutch():
+		a.StartHeight -= height
+		a.Deadline -= height
+	case a.IsTimed():
+	case a.IsSealed():
+		a.Deadline -= height
+		a.RevealDeadline -= height
+	default:
+		a.Deadline -= height
+	}
+}
+
+// ClampDeadlines moves the deadline heights that are below 0 after a rebase to 0, so an
+// overdue auction settles in the first block of the new chain
+func (a *Auction) ClampDeadlines() {
+	if a.Deadline < 0 {
+		a.Deadline = 0
+	}
+	if a.RevealDeadline < 0 {
+		a.RevealDeadline = 0
+	}
+}
+
+// IsTimed reports whether the auction ends at a block time instead of a height
+func (a Auction) IsTimed() bool {
+	return !a.EndTime.IsZero()
+}
+
+// EndTimestamp returns the last block time at which a timed auction is still open
+func (a Auction) EndTimestamp() time.Time {
+	if a.IsSealed() {
+		return a.RevealEndTime
+	}
+	return a.EndTime
+}
+
+// IsOver reports whether the auction is past its deadline at the given height and block time
+func (a Auction) IsOver(height int64, now time.Time) bool {
+	if a.IsTimed() {
+		return now.After(a.EndTimestamp())
+	}
+	return height > a.EndHeight()
+}
+
+// IsCommitOver reports whether a sealed-bid auction is past its commit phase
+func (a Auction) IsCommitOver(height int64, now time.Time) bool {
+	if a.IsTimed() {
+		return now.After(a.EndTime)
+	}
+	return height > a.Deadline
+}
+
+// EstimateEnd returns the end height and end time of the auction. Only one of them is a
+// deadline, the other is estimated with the expected block time.
+func (a Auction) EstimateEnd(height int64, now time.Time, blockTime time.Duration) (int64, time.Time) {
+	if a.IsTimed() {
+		end := a.EndTimestamp()
+		blocks := int64(0)
+		if end.After(now) {
+			blocks = int64((end.Sub(now) + blockTime - 1) / blockTime)
+		}
+		return height + blocks, end
+	}
+	end := a.EndHeight()
+	return end, now.Add(time.Duration(end-height) * blockTime).UTC()
+}
+
+func (a Auction) String() string {
+	if len(a.Items) != 0 {
+		return fmt.Sprintf("Asset: %s Items: %s %s", a.Asset, strings.Join(a.Items, ","), a.describe())
+	}
+	return a.describe()
+}
+
+func (a Auction) describe() string {
+	if a.IsSealed() {
+		return strings.TrimSpace(fmt.Sprintf(`Lot: %s Owner: %s Type: %s Reserve Price: %s Commit Deadline: %d Reveal Deadline: %d`,
+			a.Lot, a.Owner, a.Type, a.ReservePrice, a.Deadline, a.RevealDeadline))
+	}
+	if a.IsDutch() {
+		return strings.TrimSpace(fmt.Sprintf(`Lot: %s Owner: %s Type: %s Start Price: %s Price Step: %s Reserve Price: %s Start Height: %d Deadline: %d`,
+			a.Lot, a.Owner, a.Type, a.StartPrice, a.PriceStep, a.ReservePrice, a.StartHeight, a.Deadline))
+	}
+	if !a.BuyNowPrice.Empty() {
+		return strings.TrimSpace(fmt.Sprintf(`Lot: %s Owner: %s Reserve Price: %s Buy Now Price: %s Bidder:%s BidPrice: %s Deadline: %d`,
+			a.Lot, a.Owner, a.ReservePrice, a.BuyNowPrice, a.Bidder, a.BidPrice, a.Deadline))
+	}
+	return strings.TrimSpace(fmt.Sprintf(`Lot: %s Owner: %s Reserve Price: %s Bidder:%s BidPrice: %s Deadline: %d`,
+		a.Lot, a.Owner, a.ReservePrice, a.Bidder, a.BidPrice, a.Deadline))
+}
+
+// Bid is an entry in the bid book of an english auction
+type Bid struct {
+	Lot    string         `json:"lot"`
+	Bidder sdk.AccAddress `json:"bidder"`
+	Price  sdk.Coins      `json:"price"`
+	Height int64          `json:"height"`
+}
+
+func (b Bid) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Lot: %s Bidder: %s Price: %s Height: %d`, b.Lot, b.Bidder, b.Price, b.Height))
+}
+
+// SealedBid is a bidder's commitment in a sealed-bid auction. BidPrice is only known once revealed.
+type SealedBid struct {
+	Lot      string         `json:"lot"`
+	Bidder   sdk.AccAddress `json:"bidder"`
+	Hash     []byte         `json:"hash"`
+	Deposit  sdk.Coins      `json:"deposit"`
+	Revealed bool           `json:"revealed"`
+	BidPrice sdk.Coins      `json:"bid_price"`
+}
+
+func NewSealedBid() SealedBid {
+	return SealedBid{}
+}
+
+func (b SealedBid) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`Lot: %s Bidder: %s Deposit: %s Revealed: %t BidPrice: %s`,
+		b.Lot, b.Bidder, b.Deposit, b.Revealed, b.BidPrice))
+}
+
+// SealedBidHash is the commitment a bidder submits for a sealed bid. Binding the lot and bidder
+// keeps a commitment from being replayed by another account or on another auction.
+func SealedBidHash(lot string, bidder sdk.AccAddress, price sdk.Coins, salt string) []byte {
+	return tmhash.Sum([]byte(fmt.Sprintf("%s/%s/%s/%s", lot, bidder, price, salt)))
+}
+
+// AuctionOutcome records how an auction ended
+type AuctionOutcome string
+
+const (
+	// OutcomeSold means the lot went to the winner
+	OutcomeSold AuctionOutcome = "sold"
+	// OutcomeNoBids means the auction ended without a valid bid
+	OutcomeNoBids AuctionOutcome = "no_bids"
+	// OutcomeInsufficientFunds means no bidder could pay at settlement, the lot stays with the
+	// seller and the auction has no winner
+	OutcomeInsufficientFunds AuctionOutcome = "insufficient_funds"
+	// OutcomeCancelled means the seller withdrew the auction
+	OutcomeCancelled AuctionOutcome = "cancelled"
+	// OutcomeVoided means the seller no longer held the lot when the auction was migrated
+	OutcomeVoided AuctionOutcome = "voided"
+)
+
+// SettledAuction is the archived record of a completed auction
+type SettledAuction struct {
+	ID            uint64         `json:"id"`
+	Auction       Auction        `json:"auction"`
+	Outcome       AuctionOutcome `json:"outcome"`
+	Winner        sdk.AccAddress `json:"winner"`
+	Price         sdk.Coins      `json:"price"`
+	SettledHeight int64          `json:"settled_height"`
+}
+
+func (s SettledAuction) String() string {
+	return strings.TrimSpace(fmt.Sprintf(`ID: %d Lot: %s Seller: %s Outcome: %s Winner: %s Price: %s Settled Height: %d`,
+		s.ID, s.Auction.Lot, s.Auction.Owner, s.Outcome, s.Winner, s.Price, s.SettledHeight))
+}
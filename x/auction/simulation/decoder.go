@@ -0,0 +1,52 @@
+package simulation
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	tmkv "github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/rune/baseapp/x/auction/internal/types"
+	"github.com/rune/baseapp/x/auction/util"
+)
+
+// DecodeStore unmarshals the KVPair's Value to the corresponding auction type
+func DecodeStore(cdc *codec.Codec, kvA, kvB tmkv.Pair) string {
+	switch {
+	case bytes.Equal(kvA.Key[:1], util.AuctionPrefix):
+		var auctionA, auctionB types.Auction
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &auctionA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &auctionB)
+		return fmt.Sprintf("%v\n%v", auctionA, auctionB)
+
+	case bytes.Equal(kvA.Key[:1], util.BidPrefix):
+		var bidA, bidB types.Bid
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &bidA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &bidB)
+		return fmt.Sprintf("%v\n%v", bidA, bidB)
+
+	case bytes.Equal(kvA.Key[:1], util.SealedBidPrefix):
+		var bidA, bidB types.SealedBid
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &bidA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &bidB)
+		return fmt.Sprintf("%v\n%v", bidA, bidB)
+
+	case bytes.Equal(kvA.Key[:1], util.AuctionQueuePrefix),
+		bytes.Equal(kvA.Key[:1], util.AuctionTimeQueuePrefix):
+		return fmt.Sprintf("lotA: %s\nlotB: %s", kvA.Value, kvB.Value)
+
+	case bytes.Equal(kvA.Key[:1], util.SettledAuctionPrefix):
+		var settledA, settledB types.SettledAuction
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &settledA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &settledB)
+		return fmt.Sprintf("%v\n%v", settledA, settledB)
+
+	case bytes.Equal(kvA.Key, util.SettledAuctionCountKey):
+		return fmt.Sprintf("countA: %d\ncountB: %d", binary.BigEndian.Uint64(kvA.Value), binary.BigEndian.Uint64(kvB.Value))
+
+	default:
+		panic(fmt.Sprintf("invalid auction key prefix %X", kvA.Key[:1]))
+	}
+}
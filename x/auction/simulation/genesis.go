@@ -0,0 +1,106 @@
+package simulation
+
+// DONTCOVER
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/rune/baseapp/x/auction/internal/types"
+)
+
+// Simulation parameter constants
+const (
+	AuctionBond            = "auction_bond"
+	EscrowBids             = "escrow_bids"
+	MinBidIncrement        = "min_bid_increment"
+	CancelCompensationRate = "cancel_compensation_rate"
+	AuctionPeriod          = "auction_period"
+	MaxSettlementsPerBlock = "max_settlements_per_block"
+)
+
+// GenAuctionBond randomized AuctionBond, in the bond denom the simulation accounts hold
+func GenAuctionBond(r *rand.Rand) sdk.Coins {
+	return sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, int64(r.Intn(100))))
+}
+
+// GenEscrowBids randomized EscrowBids
+func GenEscrowBids(r *rand.Rand) bool {
+	return r.Intn(2) == 0
+}
+
+// GenMinBidIncrement randomized MinBidIncrement
+func GenMinBidIncrement(r *rand.Rand) sdk.Coins {
+	return sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, int64(simulation.RandIntBetween(r, 1, 10))))
+}
+
+// GenCancelCompensationRate randomized CancelCompensationRate
+func GenCancelCompensationRate(r *rand.Rand) sdk.Dec {
+	return sdk.NewDecWithPrec(int64(r.Intn(101)), 2)
+}
+
+// GenAuctionPeriod randomized AuctionPeriod, short enough for auctions to settle during a run
+func GenAuctionPeriod(r *rand.Rand) int64 {
+	return int64(simulation.RandIntBetween(r, 5, 50))
+}
+
+// GenMaxSettlementsPerBlock randomized MaxSettlementsPerBlock
+func GenMaxSettlementsPerBlock(r *rand.Rand) uint64 {
+	return uint64(simulation.RandIntBetween(r, 1, 20))
+}
+
+// RandomizedGenState generates a random GenesisState for auction, without open auctions
+func RandomizedGenState(simState *module.SimulationState) {
+	var auctionBond sdk.Coins
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, AuctionBond, &auctionBond, simState.Rand,
+		func(r *rand.Rand) { auctionBond = GenAuctionBond(r) },
+	)
+
+	var escrowBids bool
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, EscrowBids, &escrowBids, simState.Rand,
+		func(r *rand.Rand) { escrowBids = GenEscrowBids(r) },
+	)
+
+	var minBidIncrement sdk.Coins
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, MinBidIncrement, &minBidIncrement, simState.Rand,
+		func(r *rand.Rand) { minBidIncrement = GenMinBidIncrement(r) },
+	)
+
+	var cancelCompensationRate sdk.Dec
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, CancelCompensationRate, &cancelCompensationRate, simState.Rand,
+		func(r *rand.Rand) { cancelCompensationRate = GenCancelCompensationRate(r) },
+	)
+
+	var auctionPeriod int64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, AuctionPeriod, &auctionPeriod, simState.Rand,
+		func(r *rand.Rand) { auctionPeriod = GenAuctionPeriod(r) },
+	)
+
+	var maxSettlementsPerBlock uint64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, MaxSettlementsPerBlock, &maxSettlementsPerBlock, simState.Rand,
+		func(r *rand.Rand) { maxSettlementsPerBlock = GenMaxSettlementsPerBlock(r) },
+	)
+
+	// sealed-bid auctions are not simulated, their periods and the timed durations keep the defaults
+	params := types.NewParams(
+		auctionBond, escrowBids, minBidIncrement, cancelCompensationRate,
+		auctionPeriod, types.DefaultSealedCommitPeriod, types.DefaultSealedRevealPeriod,
+		types.DefaultAuctionDuration, types.DefaultSealedCommitDuration, types.DefaultSealedRevealDuration,
+		maxSettlementsPerBlock, types.DefaultExpectedBlockTime, types.DefaultMinInitialReserve,
+	)
+	auctionGenesis := types.DefaultGenesisState()
+	auctionGenesis.Params = params
+
+	fmt.Printf("Selected randomly generated auction parameters:\n%s\n", codec.MustMarshalJSONIndent(simState.Cdc, params))
+	simState.GenState[types.ModuleName] = simState.Cdc.MustMarshalJSON(auctionGenesis)
+}
@@ -0,0 +1,39 @@
+package simulation
+
+// DONTCOVER
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/rune/baseapp/x/auction/internal/types"
+)
+
+const (
+	keyAuctionBond   = "AuctionBond"
+	keyEscrowBids    = "EscrowBids"
+	keyAuctionPeriod = "AuctionPeriod"
+)
+
+// ParamChanges defines the parameters that can be modified by param change proposals
+// on the simulation
+func ParamChanges(r *rand.Rand) []simulation.ParamChange {
+	return []simulation.ParamChange{
+		simulation.NewSimParamChange(types.ModuleName, keyAuctionBond,
+			func(r *rand.Rand) string {
+				return string(types.ModuleCdc.MustMarshalJSON(GenAuctionBond(r)))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, keyEscrowBids,
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("%t", GenEscrowBids(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, keyAuctionPeriod,
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", GenAuctionPeriod(r))
+			},
+		),
+	}
+}
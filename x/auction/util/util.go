@@ -0,0 +1,85 @@
+package util
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Every record family of the auction store lives under its own single byte prefix. Lots are
+// length prefixed wherever more key parts follow them, so the records of one lot can never be
+// read as records of another.
+var (
+	AuctionPrefix          = []byte{0x01}
+	BidPrefix              = []byte{0x02}
+	SealedBidPrefix        = []byte{0x03}
+	AuctionQueuePrefix     = []byte{0x04}
+	AuctionTimeQueuePrefix = []byte{0x05}
+	SettledAuctionPrefix   = []byte{0x06}
+
+	// SettledAuctionCountKey holds the id of the next archived auction
+	SettledAuctionCountKey = []byte{0x07}
+)
+
+// LegacyAuctionPrefix is the key prefix of the auctions at nameservice consensus version 1, they
+// were kept in the nameservice store next to the names. Only the store migration reads it.
+const LegacyAuctionPrefix = "Auction:"
+
+func prefixed(prefix []byte, parts ...[]byte) []byte {
+	key := append([]byte{}, prefix...)
+	for _, part := range parts {
+		key = append(key, part...)
+	}
+	return key
+}
+
+func lengthPrefixed(lot string) []byte {
+	return append(sdk.Uint64ToBigEndian(uint64(len(lot))), lot...)
+}
+
+// AuctionKey is the key of a running auction
+func AuctionKey(lot string) []byte {
+	return prefixed(AuctionPrefix, []byte(lot))
+}
+
+// SealedBidsKey is the key prefix under which all sealed bids of a lot are stored
+func SealedBidsKey(lot string) []byte {
+	return prefixed(SealedBidPrefix, lengthPrefixed(lot))
+}
+
+func SealedBidKey(lot string, bidder sdk.AccAddress) []byte {
+	return prefixed(SealedBidsKey(lot), bidder)
+}
+
+// BidsKey is the key prefix of the bid book of a lot
+func BidsKey(lot string) []byte {
+	return prefixed(BidPrefix, lengthPrefixed(lot))
+}
+
+// BidKey orders the bid book by arrival, english bids only ever go up so it is also ordered by price
+func BidKey(lot string, seq uint64) []byte {
+	return prefixed(BidsKey(lot), sdk.Uint64ToBigEndian(seq))
+}
+
+// AuctionQueueHeightKey is the prefix of the queue entries of auctions ending at endHeight.
+// Heights are big endian so the queue iterates in deadline order.
+func AuctionQueueHeightKey(endHeight int64) []byte {
+	return prefixed(AuctionQueuePrefix, sdk.Uint64ToBigEndian(uint64(endHeight)))
+}
+
+func AuctionQueueKey(endHeight int64, lot string) []byte {
+	return prefixed(AuctionQueueHeightKey(endHeight), []byte(lot))
+}
+
+// AuctionTimeQueueTimeKey is the prefix of the queue entries of timed auctions ending at endTime
+func AuctionTimeQueueTimeKey(endTime time.Time) []byte {
+	return prefixed(AuctionTimeQueuePrefix, sdk.FormatTimeBytes(endTime))
+}
+
+func AuctionTimeQueueKey(endTime time.Time, lot string) []byte {
+	return prefixed(AuctionTimeQueueTimeKey(endTime), []byte(lot))
+}
+
+func SettledAuctionKey(id uint64) []byte {
+	return prefixed(SettledAuctionPrefix, sdk.Uint64ToBigEndian(id))
+}
@@ -9,31 +9,49 @@ const (
 	ModuleName = types.ModuleName
 	RouterKey  = types.RouterKey
 	StoreKey   = types.StoreKey
-	AuctionKey = types.AuctionKey
+	AssetRoute = types.AssetRoute
+
+	ConsensusVersion  = keeper.ConsensusVersion
+	DefaultParamspace = types.DefaultParamspace
 )
 
 var (
-	NewKeeper        = keeper.NewKeeper
-	NewQuerier       = keeper.NewQuerier
-	NewMsgBuyName    = types.NewMsgBuyName
-	NewMsgSetName    = types.NewMsgSetName
-	NewMsgDeleteName = types.NewMsgDeleteName
-	NewWhois         = types.NewWhois
-	NewAuction       = types.NewMsgAuction
-	NewBid           = types.NewMsgBid
-	ModuleCdc        = types.ModuleCdc
-	RegisterCodec    = types.RegisterCodec
+	RegisterInvariants       = keeper.RegisterInvariants
+	AllInvariants            = keeper.AllInvariants
+	NewKeeper                = keeper.NewKeeper
+	NewNameAsset             = keeper.NewNameAsset
+	NewMigrations            = keeper.NewMigrations
+	NewMultiNameserviceHooks = types.NewMultiNameserviceHooks
+	NewQuerier               = keeper.NewQuerier
+	NewMsgBuyName            = types.NewMsgBuyName
+	NewMsgSetName            = types.NewMsgSetName
+	NewMsgDeleteName         = types.NewMsgDeleteName
+	NewWhois                 = types.NewWhois
+	NewWhoisRecord           = types.NewWhoisRecord
+	NewGenesisState          = types.NewGenesisState
+	DefaultGenesisState      = types.DefaultGenesisState
+	NewParams                = types.NewParams
+	DefaultParams            = types.DefaultParams
+	ParamKeyTable            = types.ParamKeyTable
+	ModuleCdc                = types.ModuleCdc
+	RegisterCodec            = types.RegisterCodec
 )
 
 type (
-	Keeper          = keeper.Keeper
-	MsgSetName      = types.MsgSetName
-	MsgBuyName      = types.MsgBuyName
-	MsgDeleteName   = types.MsgDeleteName
-	MsgAuction      = types.MsgAuction
-	MsgBid          = types.MsgBid
-	QueryResResolve = types.QueryResResolve
-	QueryResNames   = types.QueryResNames
-	Whois           = types.Whois
-	Auction         = types.Auction
+	Keeper                = keeper.Keeper
+	NameAsset             = keeper.NameAsset
+	Migrations            = keeper.Migrations
+	MigrationHandler      = keeper.MigrationHandler
+	NameserviceHooks      = types.NameserviceHooks
+	MultiNameserviceHooks = types.MultiNameserviceHooks
+	MsgSetName            = types.MsgSetName
+	MsgBuyName            = types.MsgBuyName
+	MsgDeleteName         = types.MsgDeleteName
+	QueryResResolve       = types.QueryResResolve
+	QueryResNames         = types.QueryResNames
+	Whois                 = types.Whois
+	WhoisRecord           = types.WhoisRecord
+	NameLock              = types.NameLock
+	GenesisState          = types.GenesisState
+	Params                = types.Params
 )
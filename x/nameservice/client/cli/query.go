@@ -27,8 +27,7 @@ func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 			GetCmdResolveName(storeKey, cdc),
 			GetCmdWhois(storeKey, cdc),
 			GetCmdNames(storeKey, cdc),
-			GetCmdAuction(storeKey, cdc),
-			GetCmdAuctions(storeKey, cdc),
+			GetCmdParams(storeKey, cdc),
 		)...,
 	)
 
@@ -104,40 +103,21 @@ func GetCmdNames(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	}
 }
 
-func GetCmdAuction(queryRoute string, cdc *codec.Codec) *cobra.Command {
+// GetCmdParams queries the nameservice parameters
+func GetCmdParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
-		Use:   "auction [name]",
-		Short: "auction",
-		Args:  cobra.ExactArgs(1),
+		Use:   "params",
+		Short: "Query the current nameservice parameters",
+		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
-			lot := args[0]
 
-			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/auction/%s", queryRoute, lot), nil)
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/params", queryRoute), nil)
 			if err != nil {
-				fmt.Printf("could not resolve auction %s\n", lot)
-				return nil
+				return err
 			}
-			return cliCtx.PrintOutput(string(res))
-		},
-	}
-}
-
-func GetCmdAuctions(queryRoute string, cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
-		Use:   "auctions",
-		Short: "auctions",
-		//Args:  cobra.ExactArgs(0),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			cliCtx := context.NewCLIContext().WithCodec(cdc)
-			//lot := args[0]
 
-			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/auctions", queryRoute), nil)
-			if err != nil {
-				fmt.Printf("could not resolve auctions\n")
-				return nil
-			}
-			var out types.QueryAuctions
+			var out types.Params
 			cdc.MustUnmarshalJSON(res, &out)
 			return cliCtx.PrintOutput(out)
 		},
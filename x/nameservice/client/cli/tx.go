@@ -28,8 +28,6 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 		GetCmdBuyName(cdc),
 		GetCmdSetName(cdc),
 		GetCmdDeleteName(cdc),
-		GetCmdAuctionCreate(cdc),
-		GetCmdBid(cdc),
 	)...)
 
 	return nameserviceTxCmd
@@ -112,55 +110,3 @@ func GetCmdDeleteName(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
-
-func GetCmdAuctionCreate(cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
-		Use:  "auction [name] [value]",
-		Args: cobra.ExactArgs(2),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			cliCtx := context.NewCLIContext().WithCodec(cdc)
-			inBuf := bufio.NewReader(cmd.InOrStdin())
-			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
-
-			coins, err := sdk.ParseCoins(args[1])
-			if err != nil {
-				return err
-			}
-
-			msg := types.NewMsgAuction(args[0], cliCtx.GetFromAddress(), coins)
-			err = msg.ValidateBasic()
-			if err != nil {
-				return err
-			}
-
-			// return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, msgs)
-			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
-		},
-	}
-}
-
-func GetCmdBid(cdc *codec.Codec) *cobra.Command {
-	return &cobra.Command{
-		Use:  "bid [name] [value]",
-		Args: cobra.ExactArgs(2),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			cliCtx := context.NewCLIContext().WithCodec(cdc)
-			inBuf := bufio.NewReader(cmd.InOrStdin())
-			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
-
-			coins, err := sdk.ParseCoins(args[1])
-			if err != nil {
-				return err
-			}
-
-			msg := types.NewMsgBid(args[0], cliCtx.GetFromAddress(), coins)
-			err = msg.ValidateBasic()
-			if err != nil {
-				return err
-			}
-
-			// return utils.CompleteAndBroadcastTxCLI(txBldr, cliCtx, msgs)
-			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
-		},
-	}
-}
@@ -52,9 +52,9 @@ func namesHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc
 	}
 }
 
-func auctionsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+func paramsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/auctions", storeName), nil)
+		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/params", storeName), nil)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
 			return
@@ -62,17 +62,3 @@ func auctionsHandler(cliCtx context.CLIContext, storeName string) http.HandlerFu
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
-
-func auctionHandler(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		vars := mux.Vars(r)
-		paramType := vars[restAuction]
-		res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/auction/%s", storeName, paramType), nil)
-		if err != nil {
-			rest.WriteErrorResponse(w, http.StatusNotFound, err.Error())
-			return
-		}
-
-		rest.PostProcessResponse(w, cliCtx, res)
-	}
-}
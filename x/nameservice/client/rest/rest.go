@@ -8,8 +8,7 @@ import (
 )
 
 const (
-	restName    = "name"
-	restAuction = "auction"
+	restName = "name"
 )
 
 // RegisterRoutes - Central function to define routes that get registered by the main application
@@ -20,6 +19,5 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, storeName string)
 	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}", storeName, restName), resolveNameHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/names/{%s}/whois", storeName, restName), whoIsHandler(cliCtx, storeName)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/%s/names", storeName), deleteNameHandler(cliCtx)).Methods("DELETE")
-	r.HandleFunc(fmt.Sprintf("/%s/auctions", storeName), auctionsHandler(cliCtx, storeName)).Methods("GET")
-	r.HandleFunc(fmt.Sprintf("/%s/auction/{%s}", storeName, restAuction), auctionHandler(cliCtx, storeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/%s/params", storeName), paramsHandler(cliCtx, storeName)).Methods("GET")
 }
@@ -0,0 +1,37 @@
+package nameservice
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/rune/baseapp/x/nameservice/util"
+)
+
+// RebaseRentHeights moves the rent due heights back by height for a zero-height export at that
+// height. Names keep the blocks left until their rent is due, overdue ones are charged in the
+// first block.
+func RebaseRentHeights(ctx sdk.Context, k Keeper, height int64) {
+	// collect first, the store must not be written while it is iterated
+	var names []string
+	var records []Whois
+	iterator := k.GetNamesIterator(ctx)
+	for ; iterator.Valid(); iterator.Next() {
+		var whois Whois
+		ModuleCdc.MustUnmarshalBinaryBare(iterator.Value(), &whois)
+		if whois.RentDue == 0 {
+			continue
+		}
+		names = append(names, util.NameFromWhoisKey(iterator.Key()))
+		records = append(records, whois)
+	}
+	iterator.Close()
+
+	for i, name := range names {
+		whois := records[i]
+		// a due height of zero means the name has no value
+		whois.RentDue -= height
+		if whois.RentDue < 1 {
+			whois.RentDue = 1
+		}
+		k.RestoreWhois(ctx, name, whois)
+	}
+}
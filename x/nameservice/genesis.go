@@ -2,76 +2,113 @@ package nameservice
 
 import (
 	"fmt"
+	"strings"
+
 	"github.com/rune/baseapp/x/nameservice/util"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
-type GenesisState struct {
-	WhoisRecords   []Whois   `json:"whois_records"`
-	AuctionRecords []Auction `json:"auction_records"`
+// ValidateGenesis checks the nameservice section on its own and reports every problem found
+func ValidateGenesis(data GenesisState) error {
+	var report genesisReport
+	report.checkNames(data)
+	return report.err()
 }
 
-func NewGenesisState(whoIsRecords []Whois, auctionRecords []Auction) GenesisState {
-	return GenesisState{WhoisRecords: nil, AuctionRecords: auctionRecords}
+// genesisReport collects the problems of a genesis, each prefixed by the record and field it
+// was found at
+type genesisReport []string
+
+func (r *genesisReport) addf(field string, format string, args ...interface{}) {
+	*r = append(*r, field+": "+fmt.Sprintf(format, args...))
 }
 
-func ValidateGenesis(data GenesisState) error {
-	for _, record := range data.WhoisRecords {
-		if record.Owner == nil {
-			return fmt.Errorf("invalid WhoisRecord: Value: %s. Error: Missing Owner", record.Value)
-		}
-		if record.Value == "" {
-			return fmt.Errorf("invalid WhoisRecord: Owner: %s. Error: Missing Value", record.Owner)
+func (r genesisReport) err() error {
+	if len(r) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d problems in the nameservice genesis:\n\t%s", len(r), strings.Join(r, "\n\t"))
+}
+
+// checkNames checks the params, whois records and name locks of the nameservice section
+func (r *genesisReport) checkNames(data GenesisState) {
+	if err := data.Params.Validate(); err != nil {
+		r.addf("params", "%s", err)
+	}
+
+	names := make(map[string]int, len(data.WhoisRecords))
+	for i, record := range data.WhoisRecords {
+		field := fmt.Sprintf("whois_records[%d]", i)
+		if record.Name == "" {
+			r.addf(field+".name", "missing name")
+		} else if j, ok := names[record.Name]; ok {
+			r.addf(field+".name", "duplicate name %s of whois_records[%d]", record.Name, j)
+		} else {
+			names[record.Name] = i
 		}
-		if record.Price == nil {
-			return fmt.Errorf("invalid WhoisRecord: Value: %s. Error: Missing Price", record.Value)
+		if record.Owner.Empty() {
+			r.addf(field+".owner", "missing owner")
 		}
-	}
-	for _, record := range data.AuctionRecords {
-		if record.Lot == "" {
-			return fmt.Errorf("invalid Auction: Owner: %s. Error: Missing Lot", record.Owner)
+		switch {
+		case record.RentDue < 0:
+			r.addf(field+".rent_due", "negative height %d", record.RentDue)
+		case record.RentDue != 0 && record.Value == "":
+			r.addf(field+".rent_due", "rent due at %d for a name without a value", record.RentDue)
 		}
-		if record.Owner == nil {
-			return fmt.Errorf("invalid Auction: Lot: %s. Error: Missing Owner", record.Lot)
+		switch {
+		case record.Price.Empty():
+			r.addf(field+".price", "missing price")
+		case !record.Price.IsValid():
+			r.addf(field+".price", "invalid coins %s", record.Price)
 		}
 	}
-	return nil
-}
 
-func DefaultGenesisState() GenesisState {
-	return GenesisState{
-		WhoisRecords:   []Whois{},
-		AuctionRecords: []Auction{},
+	locked := make(map[string]int, len(data.NameLocks))
+	for i, lock := range data.NameLocks {
+		field := fmt.Sprintf("name_locks[%d]", i)
+		if lock.Name == "" {
+			r.addf(field+".name", "missing name")
+		} else if j, ok := locked[lock.Name]; ok {
+			r.addf(field+".name", "duplicate lock of name %s of name_locks[%d]", lock.Name, j)
+		} else {
+			locked[lock.Name] = i
+		}
+		if lock.Lot == "" {
+			r.addf(field+".lot", "missing lot")
+		}
 	}
 }
 
 func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) []abci.ValidatorUpdate {
+	keeper.SetParams(ctx, data.Params)
 	for _, record := range data.WhoisRecords {
-		keeper.SetWhois(ctx, record.Value, record)
+		keeper.RestoreWhois(ctx, record.Name, record.Whois())
 	}
-	for _, record := range data.AuctionRecords {
-		keeper.SetAuction(ctx, util.AuctionName(record.Lot), record, true)
+	for _, lock := range data.NameLocks {
+		keeper.LockName(ctx, lock.Name, lock.Lot)
 	}
+	keeper.SetConsensusVersion(ctx, ConsensusVersion)
 	return []abci.ValidatorUpdate{}
 }
 
 func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
-	var records []Whois
-	var auctionRecords []Auction
+	records := []WhoisRecord{}
 	iterator := k.GetNamesIterator(ctx)
+	defer iterator.Close()
 	for ; iterator.Valid(); iterator.Next() {
-
-		name := string(iterator.Key())
-		whois := k.GetWhois(ctx, name)
-		records = append(records, whois)
-
+		var whois Whois
+		ModuleCdc.MustUnmarshalBinaryBare(iterator.Value(), &whois)
+		records = append(records, NewWhoisRecord(util.NameFromWhoisKey(iterator.Key()), whois))
 	}
-	for ; iterator.Valid(); iterator.Next() {
-		key := string(iterator.Key())
-		auction := k.GetRawAuction(ctx, key)
-		auctionRecords = append(auctionRecords, auction)
+
+	locks := []NameLock{}
+	locksIterator := k.GetNameLocksIterator(ctx)
+	defer locksIterator.Close()
+	for ; locksIterator.Valid(); locksIterator.Next() {
+		locks = append(locks, NameLock{Name: util.NameFromNameLockKey(locksIterator.Key()), Lot: string(locksIterator.Value())})
 	}
-	return GenesisState{WhoisRecords: records, AuctionRecords: auctionRecords}
+
+	return NewGenesisState(k.GetParams(ctx), records, locks)
 }
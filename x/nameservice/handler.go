@@ -19,10 +19,6 @@ func NewHandler(keeper Keeper) sdk.Handler {
 			return handleMsgBuyName(ctx, keeper, msg)
 		case types.MsgDeleteName:
 			return handleMsgDeleteName(ctx, keeper, msg)
-		case types.MsgAuction:
-			return handleMsgAuction(ctx, keeper, msg)
-		case types.MsgBid:
-			return handleMsgBid(ctx, keeper, msg)
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, fmt.Sprintf("Unrecognized nameservice Msg type: %v", msg.Type()))
 		}
@@ -34,13 +30,22 @@ func handleMsgSetName(ctx sdk.Context, keeper Keeper, msg types.MsgSetName) (*sd
 	if !msg.Owner.Equals(keeper.GetOwner(ctx, msg.Name)) { // Checks if the the msg sender is the same as the current owner
 		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "Incorrect Owner") // If not, throw an error
 	}
+	if keeper.IsNameLocked(ctx, msg.Name) { // The name is promised to the auction winner
+		return nil, sdkerrors.Wrap(types.ErrNameLocked, msg.Name)
+	}
 	keeper.SetName(ctx, msg.Name, msg.Value) // If so, set the name to the value specified in the msg.
 	return &sdk.Result{}, nil                // return
 }
 
 // Handle a message to buy name
 func handleMsgBuyName(ctx sdk.Context, keeper Keeper, msg types.MsgBuyName) (*sdk.Result, error) {
+	if keeper.IsNameLocked(ctx, msg.Name) {
+		return nil, sdkerrors.Wrap(types.ErrNameLocked, msg.Name)
+	}
 	// Checks if the the bid price is greater than the price paid by the current owner
+	if !keeper.HasOwner(ctx, msg.Name) && keeper.GetParams(ctx).IsPremiumName(msg.Name) {
+		return nil, sdkerrors.Wrap(types.ErrNameReserved, msg.Name)
+	}
 	if keeper.GetPrice(ctx, msg.Name).IsAllGT(msg.Bid) {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInsufficientFunds, "Bid not high enough") // If not, throw an error
 	}
@@ -50,12 +55,15 @@ func handleMsgBuyName(ctx sdk.Context, keeper Keeper, msg types.MsgBuyName) (*sd
 			return nil, err
 		}
 	} else {
-		_, err := keeper.CoinKeeper.SubtractCoins(ctx, msg.Buyer, msg.Bid) // If so, deduct the Bid amount from the sender
+		// a name nobody owned is paid to the fee collector, like the proceeds of a chain-run auction
+		err := keeper.PayRegistration(ctx, msg.Buyer, msg.Bid)
 		if err != nil {
 			return nil, err
 		}
 	}
-	keeper.SetOwner(ctx, msg.Name, msg.Buyer)
+	if err := keeper.SetOwner(ctx, msg.Name, msg.Buyer); err != nil {
+		return nil, err
+	}
 	keeper.SetPrice(ctx, msg.Name, msg.Bid)
 	return &sdk.Result{}, nil
 }
@@ -69,40 +77,10 @@ func handleMsgDeleteName(ctx sdk.Context, keeper Keeper, msg types.MsgDeleteName
 	if !msg.Owner.Equals(keeper.GetOwner(ctx, msg.Name)) {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "Incorrect Owner")
 	}
-
-	keeper.DeleteWhois(ctx, msg.Name)
-	return &sdk.Result{}, nil
-}
-
-func handleMsgAuction(ctx sdk.Context, keeper Keeper, msg types.MsgAuction) (*sdk.Result, error) {
-	if keeper.HasAuction(ctx, msg.Lot) {
-		return nil, sdkerrors.Wrap(types.ErrAuctionExist, fmt.Sprintf("Auction %s has existed", msg.Lot))
+	if keeper.IsNameLocked(ctx, msg.Name) {
+		return nil, sdkerrors.Wrap(types.ErrNameLocked, msg.Name)
 	}
-	if !msg.Owner.Equals(keeper.GetOwner(ctx, msg.Lot)) {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "Incorrect Owner")
-	}
-	if !msg.ReservePrice.IsAllPositive() {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrInsufficientFee, "Reserve Price should be positive")
-	}
-	auction := types.Auction{Lot: msg.Lot, Owner: msg.Owner, ReservePrice: msg.ReservePrice}
-	keeper.SetAuction(ctx, msg.Lot, auction, false)
-	return &sdk.Result{}, nil
-}
 
-func handleMsgBid(ctx sdk.Context, keeper Keeper, msg types.MsgBid) (*sdk.Result, error) {
-	if !keeper.IsNamePresent(ctx, msg.Lot) {
-		return nil, sdkerrors.Wrap(types.ErrNameDoesNotExist, fmt.Sprintf("Name %s is not presented", msg.Lot))
-	}
-	if !keeper.HasAuction(ctx, msg.Lot) {
-		return nil, sdkerrors.Wrap(types.ErrAuctionDoesNotExist, fmt.Sprintf("Auction %s is not existed", msg.Lot))
-	}
-	auction := keeper.GetAuction(ctx, msg.Lot)
-	if !msg.BidPrice.IsAllGT(auction.ReservePrice) {
-		return nil, sdkerrors.Wrap(types.ErrBidPriceTooLow, msg.BidPrice.String())
-	}
-	if !msg.BidPrice.IsAllGT(auction.BidPrice) {
-		return nil, sdkerrors.Wrap(types.ErrBidPriceTooLow, msg.BidPrice.String())
-	}
-	keeper.SetBid(ctx, msg.Lot, msg.BidPrice, msg.Bidder)
+	keeper.DeleteWhois(ctx, msg.Name)
 	return &sdk.Result{}, nil
 }
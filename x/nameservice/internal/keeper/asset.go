@@ -0,0 +1,103 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/rune/baseapp/x/nameservice/internal/types"
+)
+
+// NameAsset lets the auction module sell names. The names of a lot are locked while it is
+// auctioned, their owner can neither change, delete nor sell them.
+type NameAsset struct {
+	k Keeper
+}
+
+// NewNameAsset returns the nameservice asset for the auction router
+func NewNameAsset(k Keeper) NameAsset {
+	return NameAsset{k: k}
+}
+
+// Lock checks the owner owns every name, or that no name is registered for a chain-run auction.
+// The BeforeNameLocked hook can keep a name off auction.
+func (a NameAsset) Lock(ctx sdk.Context, lot string, names []string, owner sdk.AccAddress) error {
+	// the ID of a bundle can only be a registered name when the bundle sells it
+	if a.k.IsNamePresent(ctx, lot) && !containsName(names, lot) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("Bundle ID %s is a registered name", lot))
+	}
+	for _, name := range names {
+		if owner.Empty() && a.k.HasOwner(ctx, name) {
+			return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "Chain-run auctions only sell unregistered names")
+		}
+		if !owner.Empty() && !owner.Equals(a.k.GetOwner(ctx, name)) {
+			return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "Incorrect Owner")
+		}
+		if a.k.IsNameLocked(ctx, name) {
+			return sdkerrors.Wrap(types.ErrNameLocked, name)
+		}
+		if err := a.k.BeforeNameLocked(ctx, name, owner); err != nil {
+			return err
+		}
+	}
+	for _, name := range names {
+		a.k.LockName(ctx, name, lot)
+	}
+	return nil
+}
+
+// Transfer hands the names to the winner, the price paid becomes the price of each name. The
+// hooks could veto the sale in BeforeNameLocked when the auction started, a settled sale cannot
+// be stopped, so BeforeOwnerChange is not called.
+func (a NameAsset) Transfer(ctx sdk.Context, names []string, owner, winner sdk.AccAddress, price sdk.Coins) error {
+	for _, name := range names {
+		a.k.setOwner(ctx, name, winner)
+		a.k.SetPrice(ctx, name, price)
+		a.k.UnlockName(ctx, name)
+	}
+	a.k.AfterAuctionSettled(ctx, names, owner, winner, price)
+	return nil
+}
+
+// Unlock releases the names of a lot that was not sold
+func (a NameAsset) Unlock(ctx sdk.Context, names []string, owner sdk.AccAddress) error {
+	for _, name := range names {
+		a.k.UnlockName(ctx, name)
+	}
+	a.k.AfterAuctionSettled(ctx, names, owner, nil, nil)
+	return nil
+}
+
+// Locked checks every name is locked for the lot and owned by the owner, the names of a
+// chain-run auction must still be unregistered. Names hold no coins in the auction module.
+func (a NameAsset) Locked(ctx sdk.Context, lot string, names []string, owner sdk.AccAddress) (sdk.Coins, error) {
+	for _, name := range names {
+		if locked := a.k.GetNameLock(ctx, name); locked != lot {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, fmt.Sprintf("Name %s is not locked for lot %s", name, lot))
+		}
+		switch {
+		case owner.Empty() && a.k.HasOwner(ctx, name):
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, fmt.Sprintf("Name %s of a chain-run auction is registered", name))
+		case !owner.Empty() && !a.k.IsNamePresent(ctx, name):
+			return nil, sdkerrors.Wrap(types.ErrNameDoesNotExist, name)
+		case !owner.Empty() && !owner.Equals(a.k.GetOwner(ctx, name)):
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, fmt.Sprintf("Name %s is not owned by the seller", name))
+		}
+	}
+	return nil, nil
+}
+
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Claims reports whether lot is a registered name, only the auctions of names can use it as
+// their lot
+func (a NameAsset) Claims(ctx sdk.Context, lot string) bool {
+	return a.k.IsNamePresent(ctx, lot)
+}
@@ -0,0 +1,46 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rune/baseapp/x/nameservice/internal/types"
+)
+
+// Implements NameserviceHooks
+var _ types.NameserviceHooks = Keeper{}
+
+// AfterNameRegistered - call hook if registered
+func (k Keeper) AfterNameRegistered(ctx sdk.Context, name string, owner sdk.AccAddress) {
+	if k.hooks != nil {
+		k.hooks.AfterNameRegistered(ctx, name, owner)
+	}
+}
+
+// BeforeOwnerChange - call hook if registered
+func (k Keeper) BeforeOwnerChange(ctx sdk.Context, name string, from, to sdk.AccAddress) error {
+	if k.hooks != nil {
+		return k.hooks.BeforeOwnerChange(ctx, name, from, to)
+	}
+	return nil
+}
+
+// BeforeNameLocked - call hook if registered
+func (k Keeper) BeforeNameLocked(ctx sdk.Context, name string, owner sdk.AccAddress) error {
+	if k.hooks != nil {
+		return k.hooks.BeforeNameLocked(ctx, name, owner)
+	}
+	return nil
+}
+
+// AfterNameDeleted - call hook if registered
+func (k Keeper) AfterNameDeleted(ctx sdk.Context, name string, owner sdk.AccAddress) {
+	if k.hooks != nil {
+		k.hooks.AfterNameDeleted(ctx, name, owner)
+	}
+}
+
+// AfterAuctionSettled - call hook if registered
+func (k Keeper) AfterAuctionSettled(ctx sdk.Context, names []string, seller, winner sdk.AccAddress, price sdk.Coins) {
+	if k.hooks != nil {
+		k.hooks.AfterAuctionSettled(ctx, names, seller, winner, price)
+	}
+}
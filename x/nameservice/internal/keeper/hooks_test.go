@@ -0,0 +1,81 @@
+package keeper
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rune/baseapp/x/nameservice/internal/types"
+)
+
+var errVetoed = errors.New("vetoed")
+
+// vetoHooks vetoes every change of the names it holds and records the names registered
+type vetoHooks struct {
+	vetoed     map[string]bool
+	registered []string
+}
+
+var _ types.NameserviceHooks = &vetoHooks{}
+
+func (h *vetoHooks) AfterNameRegistered(_ sdk.Context, name string, _ sdk.AccAddress) {
+	h.registered = append(h.registered, name)
+}
+
+func (h *vetoHooks) BeforeOwnerChange(_ sdk.Context, name string, _, _ sdk.AccAddress) error {
+	if h.vetoed[name] {
+		return errVetoed
+	}
+	return nil
+}
+
+func (h *vetoHooks) BeforeNameLocked(_ sdk.Context, name string, _ sdk.AccAddress) error {
+	if h.vetoed[name] {
+		return errVetoed
+	}
+	return nil
+}
+
+func (h *vetoHooks) AfterNameDeleted(sdk.Context, string, sdk.AccAddress) {}
+
+func (h *vetoHooks) AfterAuctionSettled(sdk.Context, []string, sdk.AccAddress, sdk.AccAddress, sdk.Coins) {
+}
+
+func TestBeforeOwnerChangeVeto(t *testing.T) {
+	input := createTestInput(t)
+	hooks := &vetoHooks{vetoed: map[string]bool{"kept": true}}
+	input.k.SetHooks(hooks)
+	owner, buyer := newAddr(), newAddr()
+
+	// registering a name is no owner change
+	for _, name := range []string{"kept", "sold"} {
+		require.NoError(t, input.k.SetOwner(input.ctx, name, owner))
+	}
+	require.Equal(t, []string{"kept", "sold"}, hooks.registered)
+
+	require.Equal(t, errVetoed, input.k.SetOwner(input.ctx, "kept", buyer))
+	require.Equal(t, owner, input.k.GetOwner(input.ctx, "kept"))
+	require.NoError(t, input.k.SetOwner(input.ctx, "sold", buyer))
+	require.Equal(t, buyer, input.k.GetOwner(input.ctx, "sold"))
+}
+
+func TestBeforeNameLockedVeto(t *testing.T) {
+	input := createTestInput(t)
+	input.k.SetHooks(&vetoHooks{vetoed: map[string]bool{"kept": true}})
+	owner := newAddr()
+	for _, name := range []string{"kept", "sold"} {
+		require.NoError(t, input.k.SetOwner(input.ctx, name, owner))
+	}
+	asset := NewNameAsset(input.k)
+
+	// a veto on one name of a bundle keeps every name of it off auction
+	require.Equal(t, errVetoed, asset.Lock(input.ctx, "kept", []string{"kept"}, owner))
+	require.Equal(t, errVetoed, asset.Lock(input.ctx, "bundle", []string{"sold", "kept"}, owner))
+	require.False(t, input.k.IsNameLocked(input.ctx, "kept"))
+	require.False(t, input.k.IsNameLocked(input.ctx, "sold"))
+
+	require.NoError(t, asset.Lock(input.ctx, "sold", []string{"sold"}, owner))
+	require.True(t, input.k.IsNameLocked(input.ctx, "sold"))
+}
@@ -0,0 +1,44 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rune/baseapp/x/nameservice/internal/types"
+	"github.com/rune/baseapp/x/nameservice/util"
+)
+
+// RegisterInvariants registers all nameservice invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "whois-owners", WhoisOwnersInvariant(k))
+}
+
+// AllInvariants runs all invariants of the nameservice module
+func AllInvariants(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		return WhoisOwnersInvariant(k)(ctx)
+	}
+}
+
+// WhoisOwnersInvariant checks every stored Whois has an owner
+func WhoisOwnersInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		var count int
+
+		iterator := k.GetNamesIterator(ctx)
+		defer iterator.Close()
+		for ; iterator.Valid(); iterator.Next() {
+			var whois types.Whois
+			k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &whois)
+			if whois.Owner.Empty() {
+				count++
+				msg += fmt.Sprintf("\tname %s has no owner\n", util.NameFromWhoisKey(iterator.Key()))
+			}
+		}
+
+		broken := count != 0
+		return sdk.FormatInvariant(types.ModuleName, "whois-owners",
+			fmt.Sprintf("%d names without an owner\n%s", count, msg)), broken
+	}
+}
@@ -10,36 +10,97 @@ import (
 
 // Keeper of the nameservice store
 type Keeper struct {
-	storeKey   sdk.StoreKey
-	cdc        *codec.Codec
-	CoinKeeper types.BankKeeper
+	storeKey         sdk.StoreKey
+	cdc              *codec.Codec
+	CoinKeeper       types.BankKeeper
+	supplyKeeper     types.SupplyKeeper
+	feeCollectorName string
+	paramspace       types.ParamSubspace
+	hooks            types.NameserviceHooks
 }
 
 // NewKeeper creates new instances of the nameservice Keeper
-func NewKeeper(coinKeeper bank.Keeper, storeKey sdk.StoreKey, cdc *codec.Codec) Keeper {
+func NewKeeper(coinKeeper bank.Keeper, supplyKeeper types.SupplyKeeper, storeKey sdk.StoreKey, cdc *codec.Codec,
+	feeCollectorName string, paramspace types.ParamSubspace) Keeper {
 	return Keeper{
-		CoinKeeper: coinKeeper,
-		storeKey:   storeKey,
-		cdc:        cdc,
+		CoinKeeper:       coinKeeper,
+		supplyKeeper:     supplyKeeper,
+		feeCollectorName: feeCollectorName,
+		storeKey:         storeKey,
+		cdc:              cdc,
+		paramspace:       paramspace.WithKeyTable(types.ParamKeyTable()),
 	}
 }
 
-// Sets the entire Whois metadata struct for a name
+// PayRegistration pays the price of a name that had no owner to the fee collector
+func (k Keeper) PayRegistration(ctx sdk.Context, buyer sdk.AccAddress, price sdk.Coins) error {
+	return k.supplyKeeper.SendCoinsFromAccountToModule(ctx, buyer, k.feeCollectorName, price)
+}
+
+// SetHooks sets the nameservice hooks, they can only be set once
+func (k *Keeper) SetHooks(nh types.NameserviceHooks) *Keeper {
+	if k.hooks != nil {
+		panic("cannot set nameservice hooks twice")
+	}
+	k.hooks = nh
+	return k
+}
+
+// Sets the entire Whois metadata struct for a name. Every byte written is charged
+// RecordGasPerByte on top of the store write costs. A new value starts its rent period at the
+// current block, an unchanged value keeps its rent due height.
 func (k Keeper) SetWhois(ctx sdk.Context, name string, whois types.Whois) {
 	if whois.Owner.Empty() {
 		return
 	}
+	stored := k.GetWhois(ctx, name)
+	switch {
+	case whois.Value == "":
+		whois.RentDue = 0
+	case whois.Value != stored.Value:
+		whois.RentDue = ctx.BlockHeight() + k.GetParams(ctx).RentPeriod
+	default:
+		whois.RentDue = stored.RentDue
+	}
+	k.setWhois(ctx, name, whois)
+}
+
+// RestoreWhois stores a whois from genesis with the rent due height it was exported with. A
+// value without a due height starts its rent period at the current block.
+func (k Keeper) RestoreWhois(ctx sdk.Context, name string, whois types.Whois) {
+	if whois.Owner.Empty() {
+		return
+	}
+	switch {
+	case whois.Value == "":
+		whois.RentDue = 0
+	case whois.RentDue == 0:
+		whois.RentDue = ctx.BlockHeight() + k.GetParams(ctx).RentPeriod
+	}
+	k.setWhois(ctx, name, whois)
+}
+
+// setWhois stores a whois as it is and moves its entry in the rent queue to its due height
+func (k Keeper) setWhois(ctx sdk.Context, name string, whois types.Whois) {
 	store := ctx.KVStore(k.storeKey)
-	store.Set([]byte(name), k.cdc.MustMarshalBinaryBare(whois))
+	if k.IsNamePresent(ctx, name) {
+		store.Delete(util.RentQueueKey(k.GetWhois(ctx, name).RentDue, name))
+	}
+	bz := k.cdc.MustMarshalBinaryBare(whois)
+	ctx.GasMeter().ConsumeGas(k.RecordGasPerByte(ctx)*uint64(len(bz)), "nameservice record write")
+	store.Set(util.WhoisKey(name), bz)
+	if whois.RentDue != 0 {
+		store.Set(util.RentQueueKey(whois.RentDue, name), []byte(name))
+	}
 }
 
 // Gets the entire Whois metadata struct for a name
 func (k Keeper) GetWhois(ctx sdk.Context, name string) types.Whois {
 	store := ctx.KVStore(k.storeKey)
 	if !k.IsNamePresent(ctx, name) {
-		return types.NewWhois()
+		return types.NewWhois(k.GetParams(ctx).MinNamePrice)
 	}
-	bz := store.Get([]byte(name))
+	bz := store.Get(util.WhoisKey(name))
 	var whois types.Whois
 	k.cdc.MustUnmarshalBinaryBare(bz, &whois)
 	return whois
@@ -47,8 +108,11 @@ func (k Keeper) GetWhois(ctx sdk.Context, name string) types.Whois {
 
 // Deletes the entire Whois metadata struct for a name
 func (k Keeper) DeleteWhois(ctx sdk.Context, name string) {
+	whois := k.GetWhois(ctx, name)
 	store := ctx.KVStore(k.storeKey)
-	store.Delete([]byte(name))
+	store.Delete(util.RentQueueKey(whois.RentDue, name))
+	store.Delete(util.WhoisKey(name))
+	k.AfterNameDeleted(ctx, name, whois.Owner)
 }
 
 // ResolveName - returns the string that the name resolves to
@@ -73,11 +137,26 @@ func (k Keeper) GetOwner(ctx sdk.Context, name string) sdk.AccAddress {
 	return k.GetWhois(ctx, name).Owner
 }
 
-// SetOwner - sets the current owner of a name
-func (k Keeper) SetOwner(ctx sdk.Context, name string, owner sdk.AccAddress) {
+// SetOwner - sets the current owner of a name, the BeforeOwnerChange hook can veto it
+func (k Keeper) SetOwner(ctx sdk.Context, name string, owner sdk.AccAddress) error {
+	if previous := k.GetOwner(ctx, name); !previous.Empty() {
+		if err := k.BeforeOwnerChange(ctx, name, previous, owner); err != nil {
+			return err
+		}
+	}
+	k.setOwner(ctx, name, owner)
+	return nil
+}
+
+// setOwner changes the owner without asking the hooks, a name without an owner is registered
+func (k Keeper) setOwner(ctx sdk.Context, name string, owner sdk.AccAddress) {
 	whois := k.GetWhois(ctx, name)
+	registered := whois.Owner.Empty()
 	whois.Owner = owner
 	k.SetWhois(ctx, name, whois)
+	if registered {
+		k.AfterNameRegistered(ctx, name, owner)
+	}
 }
 
 // GetPrice - gets the current price of a name
@@ -95,84 +174,61 @@ func (k Keeper) SetPrice(ctx sdk.Context, name string, price sdk.Coins) {
 // Check if the name is present in the store or not
 func (k Keeper) IsNamePresent(ctx sdk.Context, name string) bool {
 	store := ctx.KVStore(k.storeKey)
-	return store.Has([]byte(name))
+	return store.Has(util.WhoisKey(name))
 }
 
-// Get an iterator over all names in which the keys are the names and the values are the whois
+// Get an iterator over all names in which the keys are whois keys and the values are the whois,
+// util.NameFromWhoisKey returns the name of a key
 func (k Keeper) GetNamesIterator(ctx sdk.Context) sdk.Iterator {
 	store := ctx.KVStore(k.storeKey)
-	return sdk.KVStorePrefixIterator(store, []byte{})
+	return sdk.KVStorePrefixIterator(store, util.WhoisPrefix)
 }
 
-// it is not the best practice, different module should be stored in different keeper.
-func (k Keeper) SetAuction(ctx sdk.Context, lot string, auction types.Auction, isGenesis bool) {
-	if auction.Owner.Empty() {
-		return
-	}
-
-	if !k.IsNamePresent(ctx, lot) {
-		return
-	}
+// RentQueueIterator iterates in due order over the names whose rent is due at or before height,
+// the values are the names
+func (k Keeper) RentQueueIterator(ctx sdk.Context, height int64) sdk.Iterator {
 	store := ctx.KVStore(k.storeKey)
-	if !isGenesis {
-		auction.Deadline = ctx.BlockHeight() + 100
-	}
-	store.Set([]byte(util.AuctionName(lot)), k.cdc.MustMarshalBinaryBare(auction))
+	return store.Iterator(util.RentQueuePrefix, sdk.PrefixEndBytes(util.RentQueueHeightKey(height)))
 }
 
-func (k Keeper) GetAuction(ctx sdk.Context, lot string) types.Auction {
-	store := ctx.KVStore(k.storeKey)
-	if !k.IsNamePresent(ctx, lot) {
-		return types.NewAuction()
+// GetDueRents returns up to limit names whose rent is due at or before height, the longest
+// overdue first
+func (k Keeper) GetDueRents(ctx sdk.Context, height int64, limit int) []string {
+	var names []string
+	iterator := k.RentQueueIterator(ctx, height)
+	defer iterator.Close()
+	for ; iterator.Valid() && len(names) < limit; iterator.Next() {
+		names = append(names, string(iterator.Value()))
 	}
-	bz := store.Get([]byte(util.AuctionName(lot)))
-	var auction types.Auction
-	k.cdc.MustUnmarshalBinaryBare(bz, &auction)
-	return auction
+	return names
 }
 
-func (k Keeper) GetRawAuction(ctx sdk.Context, lotKey string) types.Auction {
+// LockName marks a name as being sold by the auction of lot until UnlockName is called
+func (k Keeper) LockName(ctx sdk.Context, name string, lot string) {
 	store := ctx.KVStore(k.storeKey)
-	bz := store.Get([]byte(lotKey))
-	var auction types.Auction
-	k.cdc.MustUnmarshalBinaryBare(bz, &auction)
-	return auction
+	store.Set(util.NameLockKey(name), []byte(lot))
 }
 
-func (k Keeper) HasAuction(ctx sdk.Context, lot string) bool {
-	return !k.GetAuction(ctx, lot).Owner.Empty()
-}
-
-func (k Keeper) DeleteAuction(ctx sdk.Context, lot string) {
+func (k Keeper) UnlockName(ctx sdk.Context, name string) {
 	store := ctx.KVStore(k.storeKey)
-	store.Delete([]byte(util.AuctionName(lot)))
-}
-
-// do not check in keeper
-func (k Keeper) SetBid(ctx sdk.Context, lot string, price sdk.Coins, bidder sdk.AccAddress) {
-	auction := k.GetAuction(ctx, lot)
-	auction.BidPrice = price
-	auction.Bidder = bidder
-	k.SetAuction(ctx, lot, auction, false)
-}
-
-func (k Keeper) GetAuctionOwner(ctx sdk.Context, lot string) sdk.AccAddress {
-	return k.GetAuction(ctx, lot).Owner
+	store.Delete(util.NameLockKey(name))
 }
 
-func (k Keeper) GetBid(ctx sdk.Context, lot string) sdk.Coins {
-	return k.GetAuction(ctx, lot).BidPrice
-}
-
-func (k Keeper) ResolveBidder(ctx sdk.Context, lot string) string {
-	return k.GetAuction(ctx, lot).Bidder.String()
+// GetNameLock returns the lot of the auction holding the name, empty when it is not locked
+func (k Keeper) GetNameLock(ctx sdk.Context, name string) string {
+	store := ctx.KVStore(k.storeKey)
+	return string(store.Get(util.NameLockKey(name)))
 }
 
-func (k Keeper) GetDeadline(ctx sdk.Context, lot string) int64 {
-	return k.GetAuction(ctx, lot).Deadline
+// GetNameLocksIterator iterates over the locked names, the values are the lots holding them.
+// util.NameFromNameLockKey returns the name of a key
+func (k Keeper) GetNameLocksIterator(ctx sdk.Context) sdk.Iterator {
+	store := ctx.KVStore(k.storeKey)
+	return sdk.KVStorePrefixIterator(store, util.NameLockPrefix)
 }
 
-func (k Keeper) GetAuctionIterator(ctx sdk.Context) sdk.Iterator {
+// IsNameLocked reports whether a running auction holds the name
+func (k Keeper) IsNameLocked(ctx sdk.Context, name string) bool {
 	store := ctx.KVStore(k.storeKey)
-	return sdk.KVStorePrefixIterator(store, []byte(util.AuctionPrefix))
+	return store.Has(util.NameLockKey(name))
 }
@@ -0,0 +1,122 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rune/baseapp/x/nameservice/internal/types"
+	"github.com/rune/baseapp/x/nameservice/util"
+)
+
+// ConsensusVersion is the version of the state layout and encoding written by this binary.
+// Version 1 is the string keyed layout names shared with auctions, version 2 the single byte
+// prefixes of separate name and auction stores, version 3 adds the record gas and rent params,
+// the rent queue and the minimum reserve of chain-run auctions.
+const ConsensusVersion uint64 = 3
+
+// MigrationHandler moves the state from the version it is registered for to the next one
+type MigrationHandler func(ctx sdk.Context) error
+
+// Migrations is the registry of state migrations, keyed by the consensus version they migrate
+// from. Upgrade handlers run it to bring the store up to ConsensusVersion.
+type Migrations struct {
+	keeper   Keeper
+	handlers map[uint64]MigrationHandler
+}
+
+// NewMigrations creates an empty registry for the store of k
+func NewMigrations(k Keeper) *Migrations {
+	return &Migrations{keeper: k, handlers: make(map[uint64]MigrationHandler)}
+}
+
+// Register adds the migration from version from to from+1. It returns the registry so Register
+// calls can be linked, and panics when the version is already covered or not below ConsensusVersion.
+func (m *Migrations) Register(from uint64, handler MigrationHandler) *Migrations {
+	if from == 0 || from >= ConsensusVersion {
+		panic(fmt.Sprintf("nameservice migration from version %d is out of range", from))
+	}
+	if _, ok := m.handlers[from]; ok {
+		panic(fmt.Sprintf("nameservice migration from version %d is already registered", from))
+	}
+	m.handlers[from] = handler
+	return m
+}
+
+// Versions returns the versions migrations are registered for in ascending order
+func (m *Migrations) Versions() []uint64 {
+	versions := make([]uint64, 0, len(m.handlers))
+	for v := range m.handlers {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions
+}
+
+// Migrate runs every migration from the stored version up to ConsensusVersion in order and
+// records the version reached. It fails without running anything when a step is missing.
+func (m *Migrations) Migrate(ctx sdk.Context) error {
+	from := m.keeper.GetConsensusVersion(ctx)
+	for v := from; v < ConsensusVersion; v++ {
+		if _, ok := m.handlers[v]; !ok {
+			return fmt.Errorf("no nameservice migration from version %d", v)
+		}
+	}
+	for v := from; v < ConsensusVersion; v++ {
+		if err := m.handlers[v](ctx); err != nil {
+			return fmt.Errorf("nameservice migration from version %d: %w", v, err)
+		}
+		m.keeper.SetConsensusVersion(ctx, v+1)
+	}
+	return nil
+}
+
+// GetConsensusVersion returns the version the store was last migrated to. A store without a
+// version was written before versions were recorded, in the layout of version 1.
+func (k Keeper) GetConsensusVersion(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(util.ConsensusVersionKey)
+	if bz == nil {
+		return 1
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// SetConsensusVersion records the version of the state layout in the store
+func (k Keeper) SetConsensusVersion(ctx sdk.Context, version uint64) {
+	ctx.KVStore(k.storeKey).Set(util.ConsensusVersionKey, sdk.Uint64ToBigEndian(version))
+}
+
+// MigrateParams adds the params of version 3 with their default values, the stored params
+// are kept
+func (k Keeper) MigrateParams(ctx sdk.Context) {
+	params := types.DefaultParams()
+	k.paramspace.Get(ctx, types.KeyMinNamePrice, &params.MinNamePrice)
+	k.paramspace.Get(ctx, types.KeyPremiumNameLength, &params.PremiumNameLength)
+	k.SetParams(ctx, params)
+}
+
+// MigrateStore moves the whois records of consensus version 1, kept at the store root under the
+// bare name, under the whois prefix. Keys of the single byte layout are left alone, so running it
+// twice does no harm. Auctions used to share the store, the auction module has to move their
+// records out before this runs.
+func (k Keeper) MigrateStore(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+
+	// collect first, the store must not be written while it is iterated
+	var keys, values [][]byte
+	iterator := store.Iterator(nil, nil)
+	for ; iterator.Valid(); iterator.Next() {
+		if util.IsLayoutKey(iterator.Key()) {
+			continue
+		}
+		keys = append(keys, iterator.Key())
+		values = append(values, iterator.Value())
+	}
+	iterator.Close()
+
+	for i, key := range keys {
+		store.Delete(key)
+		store.Set(util.WhoisKey(string(key)), values[i])
+	}
+}
@@ -0,0 +1,51 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rune/baseapp/x/nameservice/internal/types"
+	"github.com/rune/baseapp/x/nameservice/util"
+)
+
+func TestMigrateStoreTwice(t *testing.T) {
+	input := createTestInput(t)
+	ctx, k := input.ctx, input.k
+	owner := newAddr()
+	price := sdk.NewCoins(sdk.NewInt64Coin("nametoken", 1))
+
+	// names of the new layout with a rent queue entry and a lock
+	k.SetWhois(ctx, "current", types.Whois{Value: "value", Owner: owner, Price: price})
+	k.LockName(ctx, "current", "current")
+	// names of consensus version 1 at the store root, whatever they look like
+	store := ctx.KVStore(input.key)
+	legacy := []string{"alice", "Lock:bob", "Bid:", "SettledAuctionCount"}
+	for _, name := range legacy {
+		store.Set([]byte(name), input.cdc.MustMarshalBinaryBare(types.Whois{Value: "v1", Owner: owner, Price: price}))
+	}
+
+	k.MigrateStore(ctx)
+	k.MigrateStore(ctx)
+
+	for _, name := range legacy {
+		require.False(t, store.Has([]byte(name)))
+		whois := k.GetWhois(ctx, name)
+		require.Equal(t, "v1", whois.Value, name)
+		require.Equal(t, owner, whois.Owner, name)
+		require.False(t, k.IsNameLocked(ctx, name), name)
+	}
+	require.Equal(t, "value", k.ResolveName(ctx, "current"))
+	require.Equal(t, "current", k.GetNameLock(ctx, "current"))
+	require.Equal(t, []string{"current"}, k.GetDueRents(ctx, 1+types.DefaultRentPeriod, 10))
+	require.True(t, store.Has(util.RentQueueKey(1+types.DefaultRentPeriod, "current")))
+
+	var names int
+	iterator := k.GetNamesIterator(ctx)
+	for ; iterator.Valid(); iterator.Next() {
+		names++
+	}
+	iterator.Close()
+	require.Equal(t, len(legacy)+1, names)
+}
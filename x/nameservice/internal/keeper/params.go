@@ -1,11 +1,6 @@
 package keeper
 
-/*
-// TODO: Define if your module needs Parameters, if not this can be deleted
-
 import (
-	"time"
-
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/rune/baseapp/x/nameservice/internal/types"
 )
@@ -16,8 +11,13 @@ func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
 	return params
 }
 
+// RecordGasPerByte returns the gas charged for every byte of a whois record write
+func (k Keeper) RecordGasPerByte(ctx sdk.Context) (res uint64) {
+	k.paramspace.Get(ctx, types.KeyRecordGasPerByte, &res)
+	return
+}
+
 // SetParams sets the nameservice parameters to the param space.
 func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 	k.paramspace.SetParamSet(ctx, &params)
 }
-*/
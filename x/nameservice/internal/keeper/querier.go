@@ -1,10 +1,10 @@
 package keeper
 
 import (
-	"fmt"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/rune/baseapp/x/nameservice/internal/types"
+	"github.com/rune/baseapp/x/nameservice/util"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -12,11 +12,10 @@ import (
 
 // query endpoints supported by the nameservice Querier
 const (
-	QueryResolve  = "resolve"
-	QueryWhois    = "whois"
-	QueryNames    = "names"
-	QueryAuctions = "auctions"
-	QueryAuction  = "auction"
+	QueryResolve = "resolve"
+	QueryWhois   = "whois"
+	QueryNames   = "names"
+	QueryParams  = "params"
 )
 
 // NewQuerier is the module level router for state queries
@@ -29,10 +28,8 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryWhois(ctx, path[1:], req, keeper)
 		case QueryNames:
 			return queryNames(ctx, req, keeper)
-		case QueryAuctions:
-			return queryAuctions(ctx, keeper)
-		case QueryAuction:
-			return queryAuction(ctx, path[1:], keeper)
+		case QueryParams:
+			return queryParams(ctx, keeper)
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown nameservice query endpoint")
 		}
@@ -73,7 +70,7 @@ func queryNames(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte,
 	iterator := keeper.GetNamesIterator(ctx)
 
 	for ; iterator.Valid(); iterator.Next() {
-		namesList = append(namesList, string(iterator.Key()))
+		namesList = append(namesList, util.NameFromWhoisKey(iterator.Key()))
 	}
 
 	res, err := codec.MarshalJSONIndent(keeper.cdc, namesList)
@@ -84,33 +81,8 @@ func queryNames(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte,
 	return res, nil
 }
 
-func queryAuctions(ctx sdk.Context, keeper Keeper) ([]byte, error) {
-	var auctionList types.QueryAuctions
-	iterator := keeper.GetAuctionIterator(ctx)
-	for ; iterator.Valid(); iterator.Next() {
-		value := iterator.Value()
-		var auction types.Auction
-		_ = types.ModuleCdc.UnmarshalBinaryBare(value, &auction)
-		auctionList = append(auctionList, auction.String())
-	}
-	res, err := codec.MarshalJSONIndent(keeper.cdc, auctionList)
-	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
-	}
-
-	return res, nil
-}
-
-func queryAuction(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
-	lot := path[0]
-	var msg string
-	if keeper.HasAuction(ctx, lot) {
-		auction := keeper.GetAuction(ctx, lot)
-		msg = auction.String()
-	} else {
-		msg = fmt.Sprintf("No auction %s is processing", lot)
-	}
-	res, err := codec.MarshalJSONIndent(keeper.cdc, msg)
+func queryParams(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetParams(ctx))
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
 	}
@@ -0,0 +1,74 @@
+package keeper
+
+import (
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rune/baseapp/x/nameservice/internal/types"
+	"github.com/rune/baseapp/x/nameservice/util"
+)
+
+// CollectRent charges the owners of the names whose rent is due RentPerByte for each byte of
+// their value. The rent of a value is due RentPeriod blocks after it was written and then every
+// RentPeriod blocks after it was paid; it goes to the fee collector. When the owner cannot pay,
+// the value of the name is cleared; the name keeps its owner and price. Only the due part of the
+// rent queue is read, names over MaxRentCollectionsPerBlock are charged in the next blocks.
+func (k Keeper) CollectRent(ctx sdk.Context) {
+	params := k.GetParams(ctx)
+	for _, name := range k.GetDueRents(ctx, ctx.BlockHeight(), int(params.MaxRentCollectionsPerBlock)) {
+		whois := k.GetWhois(ctx, name)
+		if !params.RentPerByte.Empty() && !k.payRent(ctx, whois, params.Rent(len(whois.Value))) {
+			size := len(whois.Value)
+			whois.Value = ""
+			k.SetWhois(ctx, name, whois)
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					types.EventTypeRentUnpaid,
+					sdk.NewAttribute(types.AttributeKeyName, name),
+					sdk.NewAttribute(types.AttributeKeyOwner, whois.Owner.String()),
+					sdk.NewAttribute(types.AttributeKeyClearedBytes, strconv.Itoa(size)),
+				),
+			)
+			continue
+		}
+		// while the rent is off the clock keeps running, so turning it on charges no back rent
+		whois.RentDue = ctx.BlockHeight() + params.RentPeriod
+		k.setWhois(ctx, name, whois)
+	}
+}
+
+// payRent moves the rent from the owner of a name to the fee collector and reports whether it
+// was paid
+func (k Keeper) payRent(ctx sdk.Context, whois types.Whois, rent sdk.Coins) bool {
+	// a failed payment must not leave its transfer events behind
+	cacheCtx, write := ctx.WithEventManager(sdk.NewEventManager()).CacheContext()
+	if err := k.supplyKeeper.SendCoinsFromAccountToModule(cacheCtx, whois.Owner, k.feeCollectorName, rent); err != nil {
+		return false
+	}
+	write()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	return true
+}
+
+// MigrateRent starts the rent period of every name with a value at the current block, the
+// values written before the rent queue existed have no due height
+func (k Keeper) MigrateRent(ctx sdk.Context) {
+	// collect first, the store must not be written while it is iterated
+	var names []string
+	var records []types.Whois
+	iterator := k.GetNamesIterator(ctx)
+	for ; iterator.Valid(); iterator.Next() {
+		var whois types.Whois
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &whois)
+		if whois.Value == "" || whois.RentDue != 0 {
+			continue
+		}
+		names = append(names, util.NameFromWhoisKey(iterator.Key()))
+		records = append(records, whois)
+	}
+	iterator.Close()
+
+	for i, name := range names {
+		k.RestoreWhois(ctx, name, records[i])
+	}
+}
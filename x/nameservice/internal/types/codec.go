@@ -9,8 +9,6 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgSetName{}, "nameservice/SetName", nil)
 	cdc.RegisterConcrete(MsgBuyName{}, "nameservice/BuyName", nil)
 	cdc.RegisterConcrete(MsgDeleteName{}, "nameservice/DeleteName", nil)
-	cdc.RegisterConcrete(MsgAuction{}, "nameservice/Auction", nil)
-	cdc.RegisterConcrete(MsgBid{}, "nameservice/Bid", nil)
 }
 
 // ModuleCdc defines the module codec
@@ -9,8 +9,7 @@ import sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 // )
 
 var (
-	ErrNameDoesNotExist    = sdkerrors.Register(ModuleName, 1, "name does not exist")
-	ErrAuctionExist        = sdkerrors.Register(ModuleName, 2, "auction exists")
-	ErrAuctionDoesNotExist = sdkerrors.Register(ModuleName, 3, "auction does not exist")
-	ErrBidPriceTooLow      = sdkerrors.Register(ModuleName, 4, "bid price is too low")
+	ErrNameDoesNotExist = sdkerrors.Register(ModuleName, 1, "name does not exist")
+	ErrNameLocked       = sdkerrors.Register(ModuleName, 11, "name is locked by an auction")
+	ErrNameReserved     = sdkerrors.Register(ModuleName, 12, "name is reserved for auction")
 )
@@ -11,5 +11,12 @@ const (
 	// TODO: Some events may not have values for that reason you want to emit that something happened.
 	// AttributeValueDoubleSign = "double_sign"
 
+	// EventTypeRentUnpaid is emitted when the value of a name is cleared for unpaid rent
+	EventTypeRentUnpaid = "rent_unpaid"
+
+	AttributeKeyName         = "name"
+	AttributeKeyOwner        = "owner"
+	AttributeKeyClearedBytes = "cleared_bytes"
+
 	AttributeValueCategory = ModuleName
 )
@@ -2,6 +2,7 @@ package types
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
 	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
@@ -19,6 +20,34 @@ as an interface so the module can not use things that are not permitted.
 TODO: Create interfaces of what you expect the other keepers to have to be able to use this module.
 */
 type BankKeeper interface {
-	SubtractCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, error)
 	SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) error
 }
+
+// SupplyKeeper pays the price of a first registration to the fee collector
+type SupplyKeeper interface {
+	SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error
+}
+
+// AccountKeeper defines the account lookups the simulation signs its transactions with
+type AccountKeeper interface {
+	GetAccount(ctx sdk.Context, addr sdk.AccAddress) authexported.Account
+}
+
+// NameserviceHooks lets other modules react to the lifecycle of names. A Before hook vetoes the
+// operation by returning an error.
+type NameserviceHooks interface {
+	// AfterNameRegistered is called when a name without an owner gets one
+	AfterNameRegistered(ctx sdk.Context, name string, owner sdk.AccAddress)
+	// BeforeOwnerChange is called before a name is handed to another account. Names sold at
+	// auction do not pass it, BeforeNameLocked is their veto and AfterAuctionSettled reports
+	// the sale.
+	BeforeOwnerChange(ctx sdk.Context, name string, from, to sdk.AccAddress) error
+	// BeforeNameLocked is called before a name is locked for an auction, the winner is not known
+	// yet. owner is empty for a chain-run auction of an unregistered name.
+	BeforeNameLocked(ctx sdk.Context, name string, owner sdk.AccAddress) error
+	// AfterNameDeleted is called once the whois record of a name is gone
+	AfterNameDeleted(ctx sdk.Context, name string, owner sdk.AccAddress)
+	// AfterAuctionSettled is called when an auction of names ends, winner is empty when the
+	// names were not sold
+	AfterAuctionSettled(ctx sdk.Context, names []string, seller, winner sdk.AccAddress, price sdk.Coins)
+}
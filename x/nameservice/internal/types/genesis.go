@@ -1,26 +1,52 @@
 package types
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// WhoisRecord is a Whois together with the name it is stored under
+type WhoisRecord struct {
+	Name  string         `json:"name"`
+	Value string         `json:"value"`
+	Owner sdk.AccAddress `json:"owner"`
+	Price sdk.Coins      `json:"price"`
+	// RentDue is kept so the rent clock carries over, zero starts it at genesis
+	RentDue int64 `json:"rent_due"`
+}
+
+// NewWhoisRecord returns the genesis record of a name
+func NewWhoisRecord(name string, whois Whois) WhoisRecord {
+	return WhoisRecord{Name: name, Value: whois.Value, Owner: whois.Owner, Price: whois.Price, RentDue: whois.RentDue}
+}
+
+// Whois returns the stored part of the record
+func (r WhoisRecord) Whois() Whois {
+	return Whois{Value: r.Value, Owner: r.Owner, Price: r.Price, RentDue: r.RentDue}
+}
+
+// NameLock records the lot of the auction holding a name
+type NameLock struct {
+	Name string `json:"name"`
+	Lot  string `json:"lot"`
+}
+
 // GenesisState - all nameservice state that must be provided at genesis
 type GenesisState struct {
-	// TODO: Fill out what is needed by the module for genesis
+	Params       Params        `json:"params"`
+	WhoisRecords []WhoisRecord `json:"whois_records"`
+	NameLocks    []NameLock    `json:"name_locks"`
 }
 
 // NewGenesisState creates a new GenesisState object
-func NewGenesisState( /* TODO: Fill out with what is needed for genesis state */ ) GenesisState {
-	return GenesisState{
-		// TODO: Fill out according to your genesis state
-	}
+func NewGenesisState(params Params, whoIsRecords []WhoisRecord, nameLocks []NameLock) GenesisState {
+	return GenesisState{Params: params, WhoisRecords: whoIsRecords, NameLocks: nameLocks}
 }
 
 // DefaultGenesisState - default GenesisState used by Cosmos Hub
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
-		// TODO: Fill out according to your genesis state, these values will be initialized but empty
+		Params:       DefaultParams(),
+		WhoisRecords: []WhoisRecord{},
+		NameLocks:    []NameLock{},
 	}
 }
-
-// ValidateGenesis validates the nameservice genesis parameters
-func ValidateGenesis(data GenesisState) error {
-	// TODO: Create a sanity check to make sure the state conforms to the modules needs
-	return nil
-}
@@ -0,0 +1,50 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// combine multiple nameservice hooks, all hook functions are run in array sequence
+type MultiNameserviceHooks []NameserviceHooks
+
+func NewMultiNameserviceHooks(hooks ...NameserviceHooks) MultiNameserviceHooks {
+	return hooks
+}
+
+func (h MultiNameserviceHooks) AfterNameRegistered(ctx sdk.Context, name string, owner sdk.AccAddress) {
+	for i := range h {
+		h[i].AfterNameRegistered(ctx, name, owner)
+	}
+}
+
+// BeforeOwnerChange stops at the first hook vetoing the change
+func (h MultiNameserviceHooks) BeforeOwnerChange(ctx sdk.Context, name string, from, to sdk.AccAddress) error {
+	for i := range h {
+		if err := h[i].BeforeOwnerChange(ctx, name, from, to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BeforeNameLocked stops at the first hook vetoing the lock
+func (h MultiNameserviceHooks) BeforeNameLocked(ctx sdk.Context, name string, owner sdk.AccAddress) error {
+	for i := range h {
+		if err := h[i].BeforeNameLocked(ctx, name, owner); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h MultiNameserviceHooks) AfterNameDeleted(ctx sdk.Context, name string, owner sdk.AccAddress) {
+	for i := range h {
+		h[i].AfterNameDeleted(ctx, name, owner)
+	}
+}
+
+func (h MultiNameserviceHooks) AfterAuctionSettled(ctx sdk.Context, names []string, seller, winner sdk.AccAddress, price sdk.Coins) {
+	for i := range h {
+		h[i].AfterAuctionSettled(ctx, names, seller, winner, price)
+	}
+}
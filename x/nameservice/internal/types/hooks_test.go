@@ -0,0 +1,78 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// logHooks appends every call to a log shared with the other hooks, it fails the before hooks with err
+type logHooks struct {
+	id  string
+	log *[]string
+	err error
+}
+
+func (h logHooks) record(call string) {
+	*h.log = append(*h.log, fmt.Sprintf("%s:%s", h.id, call))
+}
+
+func (h logHooks) AfterNameRegistered(sdk.Context, string, sdk.AccAddress) {
+	h.record("registered")
+}
+
+func (h logHooks) BeforeOwnerChange(sdk.Context, string, sdk.AccAddress, sdk.AccAddress) error {
+	h.record("owner")
+	return h.err
+}
+
+func (h logHooks) BeforeNameLocked(sdk.Context, string, sdk.AccAddress) error {
+	h.record("locked")
+	return h.err
+}
+
+func (h logHooks) AfterNameDeleted(sdk.Context, string, sdk.AccAddress) {
+	h.record("deleted")
+}
+
+func (h logHooks) AfterAuctionSettled(sdk.Context, []string, sdk.AccAddress, sdk.AccAddress, sdk.Coins) {
+	h.record("settled")
+}
+
+func TestMultiNameserviceHooksOrder(t *testing.T) {
+	var log []string
+	hooks := NewMultiNameserviceHooks(logHooks{id: "a", log: &log}, logHooks{id: "b", log: &log})
+	ctx := sdk.Context{}
+
+	hooks.AfterNameRegistered(ctx, "name", nil)
+	require.NoError(t, hooks.BeforeOwnerChange(ctx, "name", nil, nil))
+	require.NoError(t, hooks.BeforeNameLocked(ctx, "name", nil))
+	hooks.AfterNameDeleted(ctx, "name", nil)
+	hooks.AfterAuctionSettled(ctx, []string{"name"}, nil, nil, nil)
+	require.Equal(t, []string{
+		"a:registered", "b:registered",
+		"a:owner", "b:owner",
+		"a:locked", "b:locked",
+		"a:deleted", "b:deleted",
+		"a:settled", "b:settled",
+	}, log)
+}
+
+func TestMultiNameserviceHooksStopAtFirstError(t *testing.T) {
+	var log []string
+	errFirst, errSecond := errors.New("first"), errors.New("second")
+	hooks := NewMultiNameserviceHooks(
+		logHooks{id: "a", log: &log},
+		logHooks{id: "b", log: &log, err: errFirst},
+		logHooks{id: "c", log: &log, err: errSecond},
+	)
+	ctx := sdk.Context{}
+
+	require.Equal(t, errFirst, hooks.BeforeOwnerChange(ctx, "name", nil, nil))
+	require.Equal(t, errFirst, hooks.BeforeNameLocked(ctx, "name", nil))
+	require.Equal(t, []string{"a:owner", "b:owner", "a:locked", "b:locked"}, log)
+}
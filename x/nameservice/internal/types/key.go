@@ -5,12 +5,14 @@ const (
 	ModuleName = "nameservice"
 
 	// StoreKey to be used when creating the KVStore
-	StoreKey   = ModuleName
-	AuctionKey = "auction"
+	StoreKey = ModuleName
 
 	// RouterKey to be used for routing msgs
 	//RouterKey = ModuleName
 
 	// QuerierRoute to be used for querierer msgs
 	QuerierRoute = ModuleName
+
+	// AssetRoute is the auction route names are sold under
+	AssetRoute = "name"
 )
@@ -177,85 +177,3 @@ func (msg MsgDeleteName) GetSignBytes() []byte {
 func (msg MsgDeleteName) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Owner}
 }
-
-type MsgAuction struct {
-	Lot          string         `json:"lot"`
-	Owner        sdk.AccAddress `json:"owner"`
-	ReservePrice sdk.Coins      `json:"reserve_price"`
-}
-
-func NewMsgAuction(lot string, owner sdk.AccAddress, price sdk.Coins) MsgAuction {
-	return MsgAuction{
-		Lot:          lot,
-		Owner:        owner,
-		ReservePrice: price,
-	}
-}
-
-func (msg MsgAuction) Route() string { return RouterKey }
-
-// Type should return the action
-func (msg MsgAuction) Type() string { return "new_auction" }
-
-// ValidateBasic runs stateless checks on the message
-func (msg MsgAuction) ValidateBasic() error {
-	if msg.Owner.Empty() {
-		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Owner.String())
-	}
-	if len(msg.Lot) == 0 {
-		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "Name cannot be empty")
-	}
-	if !msg.ReservePrice.IsAllPositive() {
-		return sdkerrors.Wrap(sdkerrors.ErrInsufficientFunds, "Reserve Price is negative")
-	}
-	return nil
-}
-
-func (msg MsgAuction) GetSignBytes() []byte {
-	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
-}
-
-func (msg MsgAuction) GetSigners() []sdk.AccAddress {
-	return []sdk.AccAddress{msg.Owner}
-}
-
-type MsgBid struct {
-	Lot      string         `json:"lot"`
-	Bidder   sdk.AccAddress `json:"bidder"`
-	BidPrice sdk.Coins      `json:"bid_price"`
-}
-
-func NewMsgBid(lot string, bidder sdk.AccAddress, price sdk.Coins) MsgBid {
-	return MsgBid{
-		Lot:      lot,
-		Bidder:   bidder,
-		BidPrice: price,
-	}
-}
-
-func (msg MsgBid) Route() string { return RouterKey }
-
-// Type should return the action
-func (msg MsgBid) Type() string { return "bid" }
-
-// ValidateBasic runs stateless checks on the message
-func (msg MsgBid) ValidateBasic() error {
-	if msg.Bidder.Empty() {
-		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Bidder.String())
-	}
-	if len(msg.Lot) == 0 {
-		return sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "Name cannot be empty")
-	}
-	if !msg.BidPrice.IsAllPositive() {
-		return sdkerrors.Wrap(sdkerrors.ErrInsufficientFunds, "Bid Price is negative")
-	}
-	return nil
-}
-
-func (msg MsgBid) GetSignBytes() []byte {
-	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
-}
-
-func (msg MsgBid) GetSigners() []sdk.AccAddress {
-	return []sdk.AccAddress{msg.Bidder}
-}
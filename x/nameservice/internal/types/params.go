@@ -3,19 +3,34 @@ package types
 import (
 	"fmt"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 // Default parameter namespace
 const (
-	DefaultParamspace = ModuleName
-	// TODO: Define your default parameters
+	DefaultParamspace        = ModuleName
+	DefaultPremiumNameLength = uint64(3)
+	DefaultRecordGasPerByte  = uint64(10)
+	DefaultRentPeriod        = int64(17280)
+
+	DefaultMaxRentCollectionsPerBlock = uint64(100)
+)
+
+var (
+	DefaultMinNamePrice = sdk.Coins{sdk.NewInt64Coin("nametoken", 1)}
+	DefaultRentPerByte  = sdk.Coins{}
 )
 
 // Parameter store keys
 var (
-// TODO: Define your keys for the parameter store
-// KeyParamName          = []byte("ParamName")
+	KeyMinNamePrice      = []byte("MinNamePrice")
+	KeyPremiumNameLength = []byte("PremiumNameLength")
+	KeyRecordGasPerByte  = []byte("RecordGasPerByte")
+	KeyRentPerByte       = []byte("RentPerByte")
+	KeyRentPeriod        = []byte("RentPeriod")
+
+	KeyMaxRentCollectionsPerBlock = []byte("MaxRentCollectionsPerBlock")
 )
 
 // ParamKeyTable for nameservice module
@@ -25,33 +40,167 @@ func ParamKeyTable() params.KeyTable {
 
 // Params - used for initializing default parameter for nameservice at genesis
 type Params struct {
-	// TODO: Add your Paramaters to the Paramter struct
-	// KeyParamName string `json:"key_param_name"`
+	// MinNamePrice is the starting price of a name that was never owned
+	MinNamePrice sdk.Coins `json:"min_name_price" yaml:"min_name_price"`
+	// PremiumNameLength is the length up to which names are reserved for auction. Unregistered
+	// premium names cannot be claimed with buy-name, they are sold by chain-run auctions.
+	PremiumNameLength uint64 `json:"premium_name_length" yaml:"premium_name_length"`
+	// RecordGasPerByte is the gas charged for every byte of a whois record written to the store
+	RecordGasPerByte uint64 `json:"record_gas_per_byte" yaml:"record_gas_per_byte"`
+	// RentPerByte is charged to the owner of a name for every byte of its value each RentPeriod
+	// blocks, counted from the block the value was written. The value of a name whose rent is
+	// unpaid is cleared. Empty disables the rent.
+	RentPerByte sdk.Coins `json:"rent_per_byte" yaml:"rent_per_byte"`
+	RentPeriod  int64     `json:"rent_period" yaml:"rent_period"`
+	// MaxRentCollectionsPerBlock caps the names whose rent is collected in one block, the rest
+	// carry over to the next
+	MaxRentCollectionsPerBlock uint64 `json:"max_rent_collections_per_block" yaml:"max_rent_collections_per_block"`
 }
 
 // NewParams creates a new Params object
-func NewParams( /* TODO: Pass in the paramters*/ ) Params {
+func NewParams(minNamePrice sdk.Coins, premiumNameLength uint64, recordGasPerByte uint64,
+	rentPerByte sdk.Coins, rentPeriod int64, maxRentCollectionsPerBlock uint64) Params {
 	return Params{
-		// TODO: Create your Params Type
+		MinNamePrice:               minNamePrice,
+		PremiumNameLength:          premiumNameLength,
+		RecordGasPerByte:           recordGasPerByte,
+		RentPerByte:                rentPerByte,
+		RentPeriod:                 rentPeriod,
+		MaxRentCollectionsPerBlock: maxRentCollectionsPerBlock,
 	}
 }
 
 // String implements the stringer interface for Params
 func (p Params) String() string {
-	return fmt.Sprintf(`
-	// TODO: Return all the params as a string
-	`)
+	return fmt.Sprintf(`Nameservice Params:
+  MinNamePrice:               %s
+  PremiumNameLength:          %d
+  RecordGasPerByte:           %d
+  RentPerByte:                %s
+  RentPeriod:                 %d
+  MaxRentCollectionsPerBlock: %d`,
+		p.MinNamePrice, p.PremiumNameLength, p.RecordGasPerByte, p.RentPerByte, p.RentPeriod,
+		p.MaxRentCollectionsPerBlock)
 }
 
 // ParamSetPairs - Implements params.ParamSet
 func (p *Params) ParamSetPairs() params.ParamSetPairs {
 	return params.ParamSetPairs{
-		// TODO: Pair your key with the param
-		// params.NewParamSetPair(KeyParamName, &p.ParamName),
+		params.NewParamSetPair(KeyMinNamePrice, &p.MinNamePrice, validateMinNamePrice),
+		params.NewParamSetPair(KeyPremiumNameLength, &p.PremiumNameLength, validatePremiumNameLength),
+		params.NewParamSetPair(KeyRecordGasPerByte, &p.RecordGasPerByte, validateRecordGasPerByte),
+		params.NewParamSetPair(KeyRentPerByte, &p.RentPerByte, validateRentPerByte),
+		params.NewParamSetPair(KeyRentPeriod, &p.RentPeriod, validateRentPeriod),
+		params.NewParamSetPair(KeyMaxRentCollectionsPerBlock, &p.MaxRentCollectionsPerBlock, validateMaxRentCollections),
 	}
 }
 
 // DefaultParams defines the parameters for this module
 func DefaultParams() Params {
-	return NewParams( /* TODO: Pass in your default Params */ )
+	return NewParams(DefaultMinNamePrice, DefaultPremiumNameLength, DefaultRecordGasPerByte,
+		DefaultRentPerByte, DefaultRentPeriod, DefaultMaxRentCollectionsPerBlock)
+}
+
+// Validate checks every parameter
+func (p Params) Validate() error {
+	if err := validateMinNamePrice(p.MinNamePrice); err != nil {
+		return err
+	}
+	if err := validatePremiumNameLength(p.PremiumNameLength); err != nil {
+		return err
+	}
+	if err := validateRecordGasPerByte(p.RecordGasPerByte); err != nil {
+		return err
+	}
+	if err := validateRentPerByte(p.RentPerByte); err != nil {
+		return err
+	}
+	if err := validateRentPeriod(p.RentPeriod); err != nil {
+		return err
+	}
+	return validateMaxRentCollections(p.MaxRentCollectionsPerBlock)
+}
+
+// IsPremiumName reports whether a name is reserved for auction
+func (p Params) IsPremiumName(name string) bool {
+	return uint64(len(name)) <= p.PremiumNameLength
+}
+
+// Rent returns the rent of a value of size bytes for one period
+func (p Params) Rent(size int) sdk.Coins {
+	rent := sdk.Coins{}
+	for _, coin := range p.RentPerByte {
+		rent = append(rent, sdk.NewCoin(coin.Denom, coin.Amount.MulRaw(int64(size))))
+	}
+	return rent
+}
+
+func validateMinNamePrice(i interface{}) error {
+	v, ok := i.(sdk.Coins)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if !v.IsValid() || v.Empty() {
+		return fmt.Errorf("min name price must be valid positive coins: %s", v)
+	}
+
+	return nil
+}
+
+func validatePremiumNameLength(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateRecordGasPerByte(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateRentPerByte(i interface{}) error {
+	v, ok := i.(sdk.Coins)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if !v.IsValid() {
+		return fmt.Errorf("rent per byte must be valid coins: %s", v)
+	}
+
+	return nil
+}
+
+func validateRentPeriod(i interface{}) error {
+	v, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("rent period must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func validateMaxRentCollections(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v == 0 {
+		return fmt.Errorf("max rent collections per block must be positive")
+	}
+
+	return nil
 }
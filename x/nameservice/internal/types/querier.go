@@ -19,9 +19,3 @@ type QueryResNames []string
 func (n QueryResNames) String() string {
 	return strings.Join(n[:], "\n")
 }
-
-type QueryAuctions []string
-
-func (n QueryAuctions) String() string {
-	return strings.Join(n[:], "\n")
-}
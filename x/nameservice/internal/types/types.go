@@ -7,20 +7,20 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
-// MinNamePrice is Initial Starting Price for a name that was never previously owned
-var MinNamePrice = sdk.Coins{sdk.NewInt64Coin("nametoken", 1)}
-
 // Whois is a struct that contains all the metadata of a name
 type Whois struct {
 	Value string         `json:"value"`
 	Owner sdk.AccAddress `json:"owner"`
 	Price sdk.Coins      `json:"price"`
+	// RentDue is the height the next rent of the value is collected at, zero while the name has
+	// no value. Writing a new value starts a new rent period.
+	RentDue int64 `json:"rent_due"`
 }
 
 // NewWhois returns a new Whois with the minprice as the price
-func NewWhois() Whois {
+func NewWhois(minPrice sdk.Coins) Whois {
 	return Whois{
-		Price: MinNamePrice,
+		Price: minPrice,
 	}
 }
 
@@ -28,23 +28,6 @@ func NewWhois() Whois {
 func (w Whois) String() string {
 	return strings.TrimSpace(fmt.Sprintf(`Owner: %s
 Value: %s
-Price: %s`, w.Owner, w.Value, w.Price))
-}
-
-type Auction struct {
-	Lot          string         `json:"lot"`
-	Owner        sdk.AccAddress `json:"owner"`
-	ReservePrice sdk.Coins      `json:"reserve_price"`
-	Bidder       sdk.AccAddress `json:"bidder"`
-	BidPrice     sdk.Coins      `json:"bid_price"`
-	Deadline     int64          `json:"deadline"`
-}
-
-func NewAuction() Auction {
-	return Auction{}
-}
-
-func (a Auction) String() string {
-	return strings.TrimSpace(fmt.Sprintf(`Lot: %s Owner: %s Reserve Price: %s Bidder:%s BidPrice: %s Deadline: %d`,
-		a.Lot, a.Owner, a.ReservePrice, a.Bidder, a.BidPrice, a.Deadline))
+Price: %s
+RentDue: %d`, w.Owner, w.Value, w.Price, w.RentDue))
 }
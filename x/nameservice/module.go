@@ -2,6 +2,8 @@ package nameservice
 
 import (
 	"encoding/json"
+	"math/rand"
+
 	"github.com/cosmos/cosmos-sdk/x/bank"
 
 	"github.com/gorilla/mux"
@@ -13,13 +15,17 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
 	"github.com/rune/baseapp/x/nameservice/client/cli"
 	"github.com/rune/baseapp/x/nameservice/client/rest"
+	"github.com/rune/baseapp/x/nameservice/internal/types"
+	nssim "github.com/rune/baseapp/x/nameservice/simulation"
 )
 
 var (
-	_ module.AppModule      = AppModule{}
-	_ module.AppModuleBasic = AppModuleBasic{}
+	_ module.AppModule           = AppModule{}
+	_ module.AppModuleBasic      = AppModuleBasic{}
+	_ module.AppModuleSimulation = AppModule{}
 )
 
 // AppModuleBasic defines the basic application module used by the nameservice module.
@@ -74,17 +80,21 @@ func (AppModuleBasic) GetQueryCmd(cdc *codec.Codec) *cobra.Command {
 type AppModule struct {
 	AppModuleBasic
 
-	keeper     Keeper
-	bankKeeper bank.Keeper
+	keeper        Keeper
+	bankKeeper    bank.Keeper
+	accountKeeper types.AccountKeeper
+	auctionKeeper nssim.AuctionKeeper
 }
 
-// NewAppModule creates a new AppModule object
-func NewAppModule(k Keeper, bankKeeper bank.Keeper) AppModule {
+// NewAppModule creates a new AppModule object. The account and auction keepers are only
+// used by the simulation.
+func NewAppModule(k Keeper, bankKeeper bank.Keeper, accountKeeper types.AccountKeeper, auctionKeeper nssim.AuctionKeeper) AppModule {
 	return AppModule{
 		AppModuleBasic: AppModuleBasic{},
 		keeper:         k,
 		bankKeeper:     bankKeeper,
-		// TODO: Add keepers that your application depends on
+		accountKeeper:  accountKeeper,
+		auctionKeeper:  auctionKeeper,
 	}
 }
 
@@ -94,7 +104,9 @@ func (AppModule) Name() string {
 }
 
 // RegisterInvariants registers the nameservice module invariants.
-func (am AppModule) RegisterInvariants(_ sdk.InvariantRegistry) {}
+func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {
+	RegisterInvariants(ir, am.keeper)
+}
 
 // Route returns the message routing key for the nameservice module.
 func (AppModule) Route() string {
@@ -139,6 +151,37 @@ func (am AppModule) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) {
 
 // EndBlock returns the end blocker for the nameservice module. It returns no validator
 // updates.
-func (AppModule) EndBlock(_ sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	EndBlocker(ctx, am.keeper)
 	return []abci.ValidatorUpdate{}
 }
+
+//____________________________________________________________________________
+
+// AppModuleSimulation functions
+
+// GenerateGenesisState creates a randomized GenState of the nameservice module.
+func (AppModule) GenerateGenesisState(simState *module.SimulationState) {
+	nssim.RandomizedGenState(simState)
+}
+
+// ProposalContents doesn't return any content functions for governance proposals.
+func (AppModule) ProposalContents(_ module.SimulationState) []simulation.WeightedProposalContent {
+	return nil
+}
+
+// RandomizedParams creates randomized nameservice param changes for the simulator.
+func (AppModule) RandomizedParams(r *rand.Rand) []simulation.ParamChange {
+	return nssim.ParamChanges(r)
+}
+
+// RegisterStoreDecoder registers a decoder for nameservice module's types
+func (AppModule) RegisterStoreDecoder(sdr sdk.StoreDecoderRegistry) {
+	sdr[StoreKey] = nssim.DecodeStore
+}
+
+// WeightedOperations returns the buy, set, delete, auction and bid operations of the
+// nameservice module with their respective weights.
+func (am AppModule) WeightedOperations(simState module.SimulationState) []simulation.WeightedOperation {
+	return nssim.WeightedOperations(simState.AppParams, simState.Cdc, am.accountKeeper, am.keeper, am.auctionKeeper)
+}
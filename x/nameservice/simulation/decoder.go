@@ -0,0 +1,36 @@
+package simulation
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	tmkv "github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/rune/baseapp/x/nameservice/internal/types"
+	"github.com/rune/baseapp/x/nameservice/util"
+)
+
+// DecodeStore unmarshals the KVPair's Value to the corresponding nameservice type
+func DecodeStore(cdc *codec.Codec, kvA, kvB tmkv.Pair) string {
+	switch {
+	case bytes.Equal(kvA.Key[:1], util.WhoisPrefix):
+		var whoisA, whoisB types.Whois
+		cdc.MustUnmarshalBinaryBare(kvA.Value, &whoisA)
+		cdc.MustUnmarshalBinaryBare(kvB.Value, &whoisB)
+		return fmt.Sprintf("%v\n%v", whoisA, whoisB)
+
+	case bytes.Equal(kvA.Key[:1], util.NameLockPrefix):
+		return fmt.Sprintf("lotA: %s\nlotB: %s", kvA.Value, kvB.Value)
+
+	case bytes.Equal(kvA.Key[:1], util.RentQueuePrefix):
+		return fmt.Sprintf("nameA: %s\nnameB: %s", kvA.Value, kvB.Value)
+
+	case bytes.Equal(kvA.Key, util.ConsensusVersionKey):
+		return fmt.Sprintf("versionA: %d\nversionB: %d", binary.BigEndian.Uint64(kvA.Value), binary.BigEndian.Uint64(kvB.Value))
+
+	default:
+		panic(fmt.Sprintf("invalid nameservice key prefix %X", kvA.Key[:1]))
+	}
+}
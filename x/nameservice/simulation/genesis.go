@@ -0,0 +1,128 @@
+package simulation
+
+// DONTCOVER
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/rune/baseapp/x/nameservice/internal/types"
+)
+
+// Simulation parameter constants
+const (
+	MinNamePrice      = "min_name_price"
+	PremiumNameLength = "premium_name_length"
+	RecordGasPerByte  = "record_gas_per_byte"
+	RentPerByte       = "rent_per_byte"
+	RentPeriod        = "rent_period"
+
+	MaxRentCollectionsPerBlock = "max_rent_collections_per_block"
+)
+
+// GenMinNamePrice randomized MinNamePrice, in the bond denom the simulation accounts hold
+func GenMinNamePrice(r *rand.Rand) sdk.Coins {
+	return sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, int64(simulation.RandIntBetween(r, 1, 1000))))
+}
+
+// GenPremiumNameLength randomized PremiumNameLength
+func GenPremiumNameLength(r *rand.Rand) uint64 {
+	return uint64(r.Intn(6))
+}
+
+// GenRecordGasPerByte randomized RecordGasPerByte
+func GenRecordGasPerByte(r *rand.Rand) uint64 {
+	return uint64(r.Intn(50))
+}
+
+// GenRentPerByte randomized RentPerByte, the rent is off half of the time
+func GenRentPerByte(r *rand.Rand) sdk.Coins {
+	if r.Intn(2) == 0 {
+		return sdk.Coins{}
+	}
+	return sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, int64(simulation.RandIntBetween(r, 1, 100))))
+}
+
+// GenRentPeriod randomized RentPeriod, short enough for the rent to be collected during a run
+func GenRentPeriod(r *rand.Rand) int64 {
+	return int64(simulation.RandIntBetween(r, 5, 50))
+}
+
+// GenMaxRentCollectionsPerBlock randomized MaxRentCollectionsPerBlock
+func GenMaxRentCollectionsPerBlock(r *rand.Rand) uint64 {
+	return uint64(simulation.RandIntBetween(r, 1, 20))
+}
+
+// RandomizedGenState generates a random GenesisState for nameservice. About half of the
+// accounts start out owning a name.
+func RandomizedGenState(simState *module.SimulationState) {
+	var minNamePrice sdk.Coins
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, MinNamePrice, &minNamePrice, simState.Rand,
+		func(r *rand.Rand) { minNamePrice = GenMinNamePrice(r) },
+	)
+
+	var premiumNameLength uint64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, PremiumNameLength, &premiumNameLength, simState.Rand,
+		func(r *rand.Rand) { premiumNameLength = GenPremiumNameLength(r) },
+	)
+
+	var recordGasPerByte uint64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, RecordGasPerByte, &recordGasPerByte, simState.Rand,
+		func(r *rand.Rand) { recordGasPerByte = GenRecordGasPerByte(r) },
+	)
+
+	var rentPerByte sdk.Coins
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, RentPerByte, &rentPerByte, simState.Rand,
+		func(r *rand.Rand) { rentPerByte = GenRentPerByte(r) },
+	)
+
+	var rentPeriod int64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, RentPeriod, &rentPeriod, simState.Rand,
+		func(r *rand.Rand) { rentPeriod = GenRentPeriod(r) },
+	)
+
+	var maxRentCollectionsPerBlock uint64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, MaxRentCollectionsPerBlock, &maxRentCollectionsPerBlock, simState.Rand,
+		func(r *rand.Rand) { maxRentCollectionsPerBlock = GenMaxRentCollectionsPerBlock(r) },
+	)
+
+	params := types.NewParams(minNamePrice, premiumNameLength, recordGasPerByte, rentPerByte, rentPeriod,
+		maxRentCollectionsPerBlock)
+
+	records := []types.WhoisRecord{}
+	names := make(map[string]bool)
+	for _, acc := range simState.Accounts {
+		if simState.Rand.Intn(2) == 0 {
+			continue
+		}
+		name := genName(simState.Rand, params)
+		if names[name] {
+			continue
+		}
+		names[name] = true
+		whois := types.NewWhois(minNamePrice)
+		whois.Value = simulation.RandStringOfLength(simState.Rand, 20)
+		whois.Owner = acc.Address
+		records = append(records, types.NewWhoisRecord(name, whois))
+	}
+
+	nameserviceGenesis := types.NewGenesisState(params, records, []types.NameLock{})
+
+	fmt.Printf("Selected randomly generated nameservice parameters:\n%s\n", codec.MustMarshalJSONIndent(simState.Cdc, nameserviceGenesis.Params))
+	simState.GenState[types.ModuleName] = simState.Cdc.MustMarshalJSON(nameserviceGenesis)
+}
+
+// genName returns a name too long to be reserved for auction
+func genName(r *rand.Rand, params types.Params) string {
+	return simulation.RandStringOfLength(r, int(params.PremiumNameLength)+simulation.RandIntBetween(r, 1, 10))
+}
@@ -0,0 +1,265 @@
+package simulation
+
+import (
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/simapp/helpers"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/rune/baseapp/x/auction"
+	"github.com/rune/baseapp/x/nameservice/internal/keeper"
+	"github.com/rune/baseapp/x/nameservice/internal/types"
+	"github.com/rune/baseapp/x/nameservice/util"
+)
+
+// Simulation operation weights constants
+const (
+	OpWeightMsgBuyName     = "op_weight_msg_buy_name"
+	OpWeightMsgSetName     = "op_weight_msg_set_name"
+	OpWeightMsgDeleteName  = "op_weight_msg_delete_name"
+	OpWeightMsgAuctionName = "op_weight_msg_auction_name"
+	OpWeightMsgBidName     = "op_weight_msg_bid_name"
+)
+
+// Default simulation operation weights
+const (
+	DefaultWeightMsgBuyName     = 100
+	DefaultWeightMsgSetName     = 60
+	DefaultWeightMsgDeleteName  = 20
+	DefaultWeightMsgAuctionName = 40
+	DefaultWeightMsgBidName     = 80
+)
+
+// AuctionKeeper defines the auction lookups the simulation bids with
+type AuctionKeeper interface {
+	GetAuction(ctx sdk.Context, lot string) auction.Auction
+	GetParams(ctx sdk.Context) auction.Params
+}
+
+// WeightedOperations returns all the operations from the module with their respective weights
+func WeightedOperations(appParams simulation.AppParams, cdc *codec.Codec, ak types.AccountKeeper,
+	k keeper.Keeper, auk AuctionKeeper) simulation.WeightedOperations {
+
+	var weightMsgBuyName, weightMsgSetName, weightMsgDeleteName, weightMsgAuctionName, weightMsgBidName int
+	appParams.GetOrGenerate(cdc, OpWeightMsgBuyName, &weightMsgBuyName, nil,
+		func(_ *rand.Rand) { weightMsgBuyName = DefaultWeightMsgBuyName },
+	)
+	appParams.GetOrGenerate(cdc, OpWeightMsgSetName, &weightMsgSetName, nil,
+		func(_ *rand.Rand) { weightMsgSetName = DefaultWeightMsgSetName },
+	)
+	appParams.GetOrGenerate(cdc, OpWeightMsgDeleteName, &weightMsgDeleteName, nil,
+		func(_ *rand.Rand) { weightMsgDeleteName = DefaultWeightMsgDeleteName },
+	)
+	appParams.GetOrGenerate(cdc, OpWeightMsgAuctionName, &weightMsgAuctionName, nil,
+		func(_ *rand.Rand) { weightMsgAuctionName = DefaultWeightMsgAuctionName },
+	)
+	appParams.GetOrGenerate(cdc, OpWeightMsgBidName, &weightMsgBidName, nil,
+		func(_ *rand.Rand) { weightMsgBidName = DefaultWeightMsgBidName },
+	)
+
+	return simulation.WeightedOperations{
+		simulation.NewWeightedOperation(weightMsgBuyName, SimulateMsgBuyName(ak, k)),
+		simulation.NewWeightedOperation(weightMsgSetName, SimulateMsgSetName(ak, k)),
+		simulation.NewWeightedOperation(weightMsgDeleteName, SimulateMsgDeleteName(ak, k)),
+		simulation.NewWeightedOperation(weightMsgAuctionName, SimulateMsgAuctionName(ak, k, auk)),
+		simulation.NewWeightedOperation(weightMsgBidName, SimulateMsgBidName(ak, k, auk)),
+	}
+}
+
+// SimulateMsgBuyName buys a new name, or outbids the owner of a registered one
+func SimulateMsgBuyName(ak types.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		buyer, _ := simulation.RandomAcc(r, accs)
+		name, whois, registered := randomName(r, ctx, k), types.Whois{}, false
+		if r.Intn(2) == 0 {
+			name, whois, registered = randomRegisteredName(r, ctx, k)
+		}
+		switch {
+		case registered && (whois.Owner.Equals(buyer.Address) || k.IsNameLocked(ctx, name)):
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		case !registered && k.IsNamePresent(ctx, name):
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		extra := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, int64(r.Intn(100))))
+		bid := k.GetPrice(ctx, name).Add(extra...)
+		msg := types.NewMsgBuyName(name, bid, buyer.Address)
+		return deliver(r, app, ctx, chainID, ak, buyer, msg, bid)
+	}
+}
+
+// SimulateMsgSetName points an unlocked name at a new value
+func SimulateMsgSetName(ak types.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		name, owner, ok := randomOwnedName(r, ctx, k, accs)
+		if !ok {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgSetName(name, simulation.RandStringOfLength(r, 20), owner.Address)
+		return deliver(r, app, ctx, chainID, ak, owner, msg, nil)
+	}
+}
+
+// SimulateMsgDeleteName deletes an unlocked name
+func SimulateMsgDeleteName(ak types.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		name, owner, ok := randomOwnedName(r, ctx, k, accs)
+		if !ok {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgDeleteName(name, owner.Address)
+		return deliver(r, app, ctx, chainID, ak, owner, msg, nil)
+	}
+}
+
+// SimulateMsgAuctionName puts an unlocked name up for an english auction
+func SimulateMsgAuctionName(ak types.AccountKeeper, k keeper.Keeper, auk AuctionKeeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		name, owner, ok := randomOwnedName(r, ctx, k, accs)
+		if !ok || auk.GetAuction(ctx, name).Lot != "" {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		reserve := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, int64(simulation.RandIntBetween(r, 1, 1000))))
+		msg := auction.NewAuction(types.AssetRoute, name, owner.Address, reserve, auction.AuctionTypeEnglish)
+		return deliver(r, app, ctx, chainID, ak, owner, msg, auk.GetParams(ctx).AuctionBond)
+	}
+}
+
+// SimulateMsgBidName bids on a running english auction of a name
+func SimulateMsgBidName(ak types.AccountKeeper, k keeper.Keeper, auk AuctionKeeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		lot, ok := randomLot(r, ctx, k)
+		if !ok {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		a := auk.GetAuction(ctx, lot)
+		bidder, _ := simulation.RandomAcc(r, accs)
+		if a.Lot == "" || !a.IsEnglish() || a.IsOver(ctx.BlockHeight(), ctx.BlockTime()) ||
+			!a.IsAllowed(bidder.Address) || bidder.Address.Equals(a.Owner) || !a.BuyNowPrice.Empty() {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		price := a.ReservePrice
+		if !a.BidPrice.Empty() {
+			price = a.BidPrice
+		}
+		price = price.Add(sdk.NewInt64Coin(sdk.DefaultBondDenom, int64(simulation.RandIntBetween(r, 1, 100))))
+		msg := auction.NewBid(lot, bidder.Address, price)
+		return deliver(r, app, ctx, chainID, ak, bidder, msg, price)
+	}
+}
+
+// deliver signs msg by account and delivers it, paying random fees out of what is left after
+// spent. It is a no-op when the account cannot afford spent.
+func deliver(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, chainID string, ak types.AccountKeeper,
+	simAccount simulation.Account, msg sdk.Msg, spent sdk.Coins) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+	account := ak.GetAccount(ctx, simAccount.Address)
+	coins, hasNeg := account.SpendableCoins(ctx.BlockTime()).SafeSub(spent)
+	if hasNeg {
+		return simulation.NoOpMsg(types.ModuleName), nil, nil
+	}
+
+	fees, err := simulation.RandomFees(r, ctx, coins)
+	if err != nil {
+		return simulation.NoOpMsg(types.ModuleName), nil, err
+	}
+
+	tx := helpers.GenTx(
+		[]sdk.Msg{msg},
+		fees,
+		helpers.DefaultGenTxGas,
+		chainID,
+		[]uint64{account.GetAccountNumber()},
+		[]uint64{account.GetSequence()},
+		simAccount.PrivKey,
+	)
+
+	if _, _, err := app.Deliver(tx); err != nil {
+		return simulation.NoOpMsg(types.ModuleName), nil, err
+	}
+
+	return simulation.NewOperationMsg(msg, true, ""), nil, nil
+}
+
+// randomName returns a name too long to be reserved for auction under the current params
+func randomName(r *rand.Rand, ctx sdk.Context, k keeper.Keeper) string {
+	return genName(r, k.GetParams(ctx))
+}
+
+// randomRegisteredName picks a registered name, it is false when there is none
+func randomRegisteredName(r *rand.Rand, ctx sdk.Context, k keeper.Keeper) (string, types.Whois, bool) {
+	var names []string
+	iterator := k.GetNamesIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		names = append(names, util.NameFromWhoisKey(iterator.Key()))
+	}
+	if len(names) == 0 {
+		return "", types.Whois{}, false
+	}
+	name := names[r.Intn(len(names))]
+	return name, k.GetWhois(ctx, name), true
+}
+
+// randomOwnedName picks an unlocked name owned by one of the simulation accounts
+func randomOwnedName(r *rand.Rand, ctx sdk.Context, k keeper.Keeper, accs []simulation.Account) (string, simulation.Account, bool) {
+	var names []string
+	var owners []simulation.Account
+	iterator := k.GetNamesIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		name := util.NameFromWhoisKey(iterator.Key())
+		if k.IsNameLocked(ctx, name) {
+			continue
+		}
+		if owner, ok := simulation.FindAccount(accs, k.GetOwner(ctx, name)); ok {
+			names = append(names, name)
+			owners = append(owners, owner)
+		}
+	}
+	if len(names) == 0 {
+		return "", simulation.Account{}, false
+	}
+	i := r.Intn(len(names))
+	return names[i], owners[i], true
+}
+
+// randomLot picks the lot of an auction holding a name lock
+func randomLot(r *rand.Rand, ctx sdk.Context, k keeper.Keeper) (string, bool) {
+	var lots []string
+	iterator := k.GetNameLocksIterator(ctx)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		lots = append(lots, string(iterator.Value()))
+	}
+	if len(lots) == 0 {
+		return "", false
+	}
+	return lots[r.Intn(len(lots))], true
+}
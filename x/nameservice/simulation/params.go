@@ -0,0 +1,52 @@
+package simulation
+
+// DONTCOVER
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/rune/baseapp/x/nameservice/internal/types"
+)
+
+const (
+	keyMinNamePrice      = "MinNamePrice"
+	keyPremiumNameLength = "PremiumNameLength"
+	keyRecordGasPerByte  = "RecordGasPerByte"
+	keyRentPerByte       = "RentPerByte"
+
+	keyMaxRentCollectionsPerBlock = "MaxRentCollectionsPerBlock"
+)
+
+// ParamChanges defines the parameters that can be modified by param change proposals
+// on the simulation
+func ParamChanges(r *rand.Rand) []simulation.ParamChange {
+	return []simulation.ParamChange{
+		simulation.NewSimParamChange(types.ModuleName, keyMinNamePrice,
+			func(r *rand.Rand) string {
+				return string(types.ModuleCdc.MustMarshalJSON(GenMinNamePrice(r)))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, keyPremiumNameLength,
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", GenPremiumNameLength(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, keyRecordGasPerByte,
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", GenRecordGasPerByte(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, keyRentPerByte,
+			func(r *rand.Rand) string {
+				return string(types.ModuleCdc.MustMarshalJSON(GenRentPerByte(r)))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, keyMaxRentCollectionsPerBlock,
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", GenMaxRentCollectionsPerBlock(r))
+			},
+		),
+	}
+}
@@ -1,7 +1,61 @@
 package util
 
-const AuctionPrefix = "Auction:"
+import (
+	"bytes"
 
-func AuctionName(name string) string {
-	return AuctionPrefix + name
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Every record family of the nameservice store lives under its own single byte prefix, so no
+// name can collide with another kind of record.
+var (
+	WhoisPrefix    = []byte{0x01}
+	NameLockPrefix = []byte{0x02}
+
+	// ConsensusVersionKey holds the version of the state layout the store was last migrated to
+	ConsensusVersionKey = []byte{0x03}
+
+	RentQueuePrefix = []byte{0x04}
+)
+
+// IsLayoutKey reports whether key belongs to a record family of the single byte layout, every
+// other key is a name stored at the root by consensus version 1
+func IsLayoutKey(key []byte) bool {
+	for _, prefix := range [][]byte{WhoisPrefix, NameLockPrefix, ConsensusVersionKey, RentQueuePrefix} {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// WhoisKey is the key of the whois record of a name
+func WhoisKey(name string) []byte {
+	return append(append([]byte{}, WhoisPrefix...), name...)
+}
+
+// NameFromWhoisKey returns the name a whois key belongs to
+func NameFromWhoisKey(key []byte) string {
+	return string(key[len(WhoisPrefix):])
+}
+
+// NameLockKey is the key of the lock an auction holds on a name
+func NameLockKey(name string) []byte {
+	return append(append([]byte{}, NameLockPrefix...), name...)
+}
+
+// NameFromNameLockKey returns the name a lock key belongs to
+func NameFromNameLockKey(key []byte) string {
+	return string(key[len(NameLockPrefix):])
+}
+
+// RentQueueHeightKey is the prefix of the rent queue entries of names due at height. Heights are
+// big endian so the queue iterates in due order.
+func RentQueueHeightKey(height int64) []byte {
+	return append(append([]byte{}, RentQueuePrefix...), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// RentQueueKey is the entry of a name in the rent queue, the name is also the value
+func RentQueueKey(height int64, name string) []byte {
+	return append(RentQueueHeightKey(height), name...)
 }